@@ -0,0 +1,134 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ---------- Розбір адреси ----------
+// Легкий розбір вільного тексту українською на складові. Це не повноцінний
+// геокодер: частини розпізнаються за маркерами ("м.", "вул.", "буд.", "кв.")
+// та за формою (число з літерою — номер будинку, п'ять цифр — індекс).
+
+type Address struct {
+	PostalCode string
+	City       string
+	Street     string
+	Building   string
+	Apartment  string
+}
+
+var (
+	cityMarkers      = []string{"місто", "м.", "смт", "село", "с.", "селище"}
+	streetMarkers    = []string{"вулиця", "вул.", "проспект", "просп.", "пр-т", "провулок", "пров.", "бульвар", "бульв.", "б-р", "площа", "пл.", "шосе", "набережна", "наб.", "узвіз"}
+	buildingMarkers  = []string{"будинок", "буд.", "б."}
+	apartmentMarkers = []string{"квартира", "кв.", "офіс", "оф."}
+
+	postalCodeRe = regexp.MustCompile(`^\d{5}$`)
+	buildingRe   = regexp.MustCompile(`^\d+[а-яіїєґА-ЯІЇЄҐ]?(/\d+[а-яіїєґА-ЯІЇЄҐ]?)?$`)
+	// Назва вулиці з номером будинку в кінці: "вул. Хрещатик 22"
+	streetWithNumberRe = regexp.MustCompile(`^(.*\S)\s+(\d+[а-яіїєґА-ЯІЇЄҐ]?(/\d+[а-яіїєґА-ЯІЇЄҐ]?)?)$`)
+)
+
+// Якщо part починається з одного з маркерів, повертає решту рядка
+func cutMarker(part string, markers []string) (string, bool) {
+	lower := strings.ToLower(part)
+	for _, m := range markers {
+		if strings.HasPrefix(lower, m) {
+			rest := part[len(m):]
+			// Маркер без крапки має бути окремим словом: "шосе" не спрацьовує на "Шосейна"
+			if !strings.HasSuffix(m, ".") && rest != "" && rest[0] != ' ' {
+				continue
+			}
+			return strings.TrimSpace(rest), true
+		}
+	}
+	return "", false
+}
+
+// Розбирає адресу на складові
+func parseAddress(text string) Address {
+	var addr Address
+	var unmarked []string
+
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if rest, ok := cutMarker(part, apartmentMarkers); ok {
+			addr.Apartment = rest
+		} else if rest, ok := cutMarker(part, buildingMarkers); ok {
+			addr.Building = rest
+		} else if _, ok := cutMarker(part, streetMarkers); ok {
+			addr.Street = part
+			if m := streetWithNumberRe.FindStringSubmatch(part); m != nil {
+				addr.Street, addr.Building = m[1], m[2]
+			}
+		} else if rest, ok := cutMarker(part, cityMarkers); ok {
+			addr.City = rest
+		} else if postalCodeRe.MatchString(part) {
+			addr.PostalCode = part
+		} else if buildingRe.MatchString(part) {
+			if addr.Building == "" {
+				addr.Building = part
+			} else {
+				addr.Apartment = part
+			}
+		} else {
+			unmarked = append(unmarked, part)
+		}
+	}
+
+	// Частина без маркера до вулиці найчастіше є назвою міста
+	if addr.City == "" && len(unmarked) > 0 {
+		addr.City = unmarked[0]
+		unmarked = unmarked[1:]
+	}
+	if addr.Street == "" && len(unmarked) > 0 {
+		addr.Street = unmarked[0]
+		if m := streetWithNumberRe.FindStringSubmatch(addr.Street); m != nil && addr.Building == "" {
+			addr.Street, addr.Building = m[1], m[2]
+		}
+	}
+	return addr
+}
+
+// Повертає назви відсутніх складових адреси
+func (a Address) Missing() []string {
+	var missing []string
+	if a.City == "" {
+		missing = append(missing, "місто")
+	}
+	if a.Street == "" {
+		missing = append(missing, "вулиця")
+	}
+	if a.Building == "" {
+		missing = append(missing, "будинок")
+	}
+	if a.Apartment == "" {
+		missing = append(missing, "квартира")
+	}
+	return missing
+}
+
+// Перевірка адреси: місто, вулиця та будинок обов'язкові, квартира — ні.
+// Якщо вказано індекс, він перевіряється як український.
+func validateAddress(text string) (bool, []string) {
+	var errors []string
+	addr := parseAddress(text)
+
+	for _, part := range addr.Missing() {
+		if part != "квартира" {
+			errors = append(errors, "Не вказано: "+part)
+		}
+	}
+	if addr.PostalCode != "" {
+		if _, errs := validatePostalCode(addr.PostalCode, "UA"); len(errs) > 0 {
+			errors = append(errors, errs...)
+		}
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------- Перевірка пароля у витоках (k-anonymity) ----------
+// Пароль хешується SHA-1, назовні передаються лише перші 5 символів
+// хешу. Джерело повертає всі суфікси з цим префіксом, а збіг шукається
+// локально. Повний хеш ніколи не надсилається і не виводиться.
+
+const hashPrefixLen = 5
+
+// Джерело діапазонів хешів: за префіксом повертає рядки "СУФІКС:КІЛЬКІСТЬ"
+type rangeSource interface {
+	Range(prefix string) (io.ReadCloser, error)
+}
+
+// Локальне джерело: каталог з файлами по префіксах (00000, 00000.txt, ...)
+// або один файл з повними хешами у форматі "ХЕШ:КІЛЬКІСТЬ".
+type fileRangeSource struct {
+	path string
+}
+
+// HTTP-джерело, сумісне з API HIBP: GET {baseURL}/range/{prefix}
+type httpRangeSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// Створює джерело за рядком конфігурації: http(s)://... або шлях на диску
+func newRangeSource(spec string) rangeSource {
+	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+		return &httpRangeSource{
+			baseURL: strings.TrimSuffix(spec, "/"),
+			client:  &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+	return &fileRangeSource{path: spec}
+}
+
+func (s *fileRangeSource) Range(prefix string) (io.ReadCloser, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		for _, name := range []string{prefix, prefix + ".txt"} {
+			file, err := os.Open(filepath.Join(s.path, name))
+			if err == nil {
+				return file, nil
+			}
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+		}
+		// Немає файлу для префікса — отже, жоден хеш з ним не відомий
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Вибираємо з повного списку рядки з потрібним префіксом
+	// і відрізаємо префікс, як це робить API.
+	var out strings.Builder
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) > hashPrefixLen && strings.EqualFold(line[:hashPrefixLen], prefix) {
+			out.WriteString(line[hashPrefixLen:])
+			out.WriteString("\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader(out.String())), nil
+}
+
+func (s *httpRangeSource) Range(prefix string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, s.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Доповнення відповіді фіктивними записами ускладнює аналіз трафіку
+	req.Header.Set("Add-Padding", "true")
+	req.Header.Set("User-Agent", "hw2-validator")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("сервер відповів %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// Повертає, скільки разів пароль траплявся у витоках (0 — не знайдено).
+func pwnedCount(source rangeSource, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:hashPrefixLen], hash[hashPrefixLen:]
+
+	body, err := source.Range(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("не вдалося отримати діапазон %s: %w", prefix, err)
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		candidate, countStr, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if err != nil {
+			return 0, fmt.Errorf("некоректний рядок у діапазоні %s", prefix)
+		}
+		// Записи доповнення мають кількість 0 і не означають витоку
+		return count, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("помилка читання діапазону %s: %w", prefix, err)
+	}
+	return 0, nil
+}
+
+// Перевіряє пароль у джерелі з HW2_PWNED_SOURCE. Якщо джерело не задане,
+// перевірка пропускається. Повертає причини невалідності.
+func checkPasswordBreach(password string) []string {
+	spec := os.Getenv("HW2_PWNED_SOURCE")
+	if spec == "" {
+		return nil
+	}
+
+	count, err := pwnedCount(newRangeSource(spec), password)
+	if err != nil {
+		return []string{"Не вдалося перевірити пароль у витоках: " + err.Error()}
+	}
+	if count > 0 {
+		return []string{fmt.Sprintf("Пароль знайдено у витоках даних %d раз(и)", count)}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Префікс і суфікс SHA-1 пароля, як їх бачить джерело діапазонів
+func splitHash(password string) (prefix, suffix string) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return hash[:hashPrefixLen], hash[hashPrefixLen:]
+}
+
+// Заглушка API: віддає діапазон для одного префікса (для інших — порожній,
+// як справжній сервіс) і запам'ятовує шляхи запитів
+func newRangeStub(t *testing.T, prefix, body string, status int) (*httptest.Server, *[]string) {
+	t.Helper()
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		if status != http.StatusOK {
+			http.Error(w, "boom", status)
+			return
+		}
+		if r.URL.Path == "/range/"+prefix {
+			fmt.Fprint(w, body)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &paths
+}
+
+func TestPwnedCountHTTP(t *testing.T) {
+	prefix, suffix := splitHash("password1")
+	body := "0018A45C4D1DEF81644B54AB7F969B88D65:3\r\n" +
+		strings.ToLower(suffix) + ":42\r\n" +
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:0\r\n" // запис доповнення
+	srv, paths := newRangeStub(t, prefix, body, http.StatusOK)
+
+	count, err := pwnedCount(newRangeSource(srv.URL+"/"), "password1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 42 {
+		t.Errorf("count = %d, want 42", count)
+	}
+
+	if len(*paths) != 1 || (*paths)[0] != "/range/"+prefix {
+		t.Fatalf("requests = %q, want only /range/%s", *paths, prefix)
+	}
+	if strings.Contains(strings.ToUpper((*paths)[0]), suffix) {
+		t.Errorf("request %q leaks the hash suffix", (*paths)[0])
+	}
+}
+
+func TestPwnedCountHTTPNotFound(t *testing.T) {
+	prefix, _ := splitHash("Tr0ub4dor&3x!")
+	srv, _ := newRangeStub(t, prefix, "0018A45C4D1DEF81644B54AB7F969B88D65:3\n", http.StatusOK)
+
+	count, err := pwnedCount(newRangeSource(srv.URL), "Tr0ub4dor&3x!")
+	if err != nil || count != 0 {
+		t.Errorf("count, err = %d, %v; want 0, nil", count, err)
+	}
+}
+
+func TestPwnedCountHTTPServerError(t *testing.T) {
+	prefix, _ := splitHash("password1")
+	srv, _ := newRangeStub(t, prefix, "", http.StatusInternalServerError)
+
+	if _, err := pwnedCount(newRangeSource(srv.URL), "password1"); err == nil {
+		t.Fatal("expected error on 500")
+	}
+
+	// Помилка джерела — це причина невалідності, а не мовчазний пропуск
+	t.Setenv("HW2_PWNED_SOURCE", srv.URL)
+	reasons := checkPasswordBreach("password1")
+	if len(reasons) != 1 || !strings.Contains(reasons[0], "Не вдалося перевірити") {
+		t.Errorf("reasons = %q", reasons)
+	}
+}
+
+func TestPwnedCountFileDir(t *testing.T) {
+	prefix, suffix := splitHash("qwerty")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, prefix+".txt"), []byte(suffix+":7\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	source := newRangeSource(dir)
+
+	count, err := pwnedCount(source, "qwerty")
+	if err != nil || count != 7 {
+		t.Errorf("count, err = %d, %v; want 7, nil", count, err)
+	}
+
+	// Файлу для префікса немає — пароль у витоках невідомий
+	count, err = pwnedCount(source, "Tr0ub4dor&3x!")
+	if err != nil || count != 0 {
+		t.Errorf("missing range file: count, err = %d, %v; want 0, nil", count, err)
+	}
+}
+
+func TestPwnedCountFileList(t *testing.T) {
+	prefix, suffix := splitHash("qwerty")
+	list := filepath.Join(t.TempDir(), "pwned.txt")
+	data := "0000000000000000000000000000000000000000:1\n" + prefix + suffix + ":12\n"
+	if err := os.WriteFile(list, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := pwnedCount(newRangeSource(list), "qwerty")
+	if err != nil || count != 12 {
+		t.Errorf("count, err = %d, %v; want 12, nil", count, err)
+	}
+
+	if _, err := pwnedCount(newRangeSource(filepath.Join(t.TempDir(), "nope")), "qwerty"); err == nil {
+		t.Error("expected error for missing source path")
+	}
+}
+
+func TestCheckPasswordBreachFound(t *testing.T) {
+	prefix, suffix := splitHash("password1")
+	srv, _ := newRangeStub(t, prefix, suffix+":5\n", http.StatusOK)
+	t.Setenv("HW2_PWNED_SOURCE", srv.URL)
+
+	reasons := checkPasswordBreach("password1")
+	if len(reasons) != 1 || !strings.Contains(reasons[0], "5 раз") {
+		t.Errorf("reasons = %q", reasons)
+	}
+	if reasons := checkPasswordBreach("Tr0ub4dor&3x!"); len(reasons) != 0 {
+		t.Errorf("unknown password: reasons = %q", reasons)
+	}
+}
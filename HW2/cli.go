@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golanghomeworks/validate"
+)
+
+// ---------- Командний рядок ----------
+// hw2 check <тип> <значення>    — перевірка одного значення
+// hw2 batch <тип> <файл | ->    — перевірка кожного рядка файлу
+// hw2 kinds                     — список типів
+// hw2 serve [адреса]            — HTTP-сервіс валідації (за замовчуванням :8080)
+// hw2 generate ...              — генерація пароля або фрази
+// hw2 update-tld | update-psl   — оновлення знімків доменних списків
+
+// Виконує команду з аргументів командного рядка та повертає код виходу
+func runCommand(args []string) int {
+	switch args[0] {
+	case "update-tld", "update-psl":
+		if len(args) != 2 {
+			fmt.Println("Використання: hw2 " + args[0] + " <файл>")
+			return 2
+		}
+		fileName := validate.TLDFileName
+		if args[0] == "update-psl" {
+			fileName = validate.PSLFileName
+		}
+		if err := validate.UpdateDomainList(fileName, args[1]); err != nil {
+			fmt.Println(Red+"Помилка оновлення:"+Reset, err)
+			return 1
+		}
+		fmt.Println(Green + "Список оновлено: " + fileName + Reset)
+		return 0
+
+	case "generate":
+		if len(args) < 2 {
+			fmt.Println("Використання: hw2 generate password [довжина] | passphrase [слів] [uk|en]")
+			return 2
+		}
+		var secret string
+		var entropy float64
+		var err error
+		switch args[1] {
+		case "password":
+			length := 16
+			if len(args) > 2 {
+				length, _ = strconv.Atoi(args[2])
+			}
+			secret, entropy, err = generatePassword(length)
+		case "passphrase":
+			count, lang := 6, "uk"
+			if len(args) > 2 {
+				count, _ = strconv.Atoi(args[2])
+			}
+			if len(args) > 3 {
+				lang = args[3]
+			}
+			secret, entropy, err = generatePassphrase(count, lang, "-")
+		default:
+			err = fmt.Errorf("невідомий тип: %s", args[1])
+		}
+		printGenerated(secret, entropy, err)
+		if err != nil {
+			return 1
+		}
+		return 0
+
+	case "check":
+		if len(args) < 3 {
+			fmt.Println("Використання: hw2 check <тип> <значення>")
+			return 2
+		}
+		return runCheck(args[1], strings.Join(args[2:], " "))
+
+	case "batch":
+		if len(args) != 3 {
+			fmt.Println("Використання: hw2 batch <тип> <файл | ->")
+			return 2
+		}
+		return runBatch(args[1], args[2])
+
+	case "serve":
+		addr := ":8080"
+		if len(args) > 1 {
+			addr = args[1]
+		}
+		if err := serve(addr); err != nil {
+			fmt.Println(Red+"Помилка сервера:"+Reset, err)
+			return 1
+		}
+		return 0
+
+	case "kinds":
+		for _, kind := range validatorKinds() {
+			fmt.Printf("%-10s %s\n", kind, validatorRegistry[kind].Title)
+		}
+		return 0
+
+	default:
+		fmt.Println(Red + "Невідома команда: " + args[0] + Reset)
+		fmt.Println("Доступні команди: check <тип> <значення>, batch <тип> <файл>, kinds, serve [адреса],")
+		fmt.Println("update-tld <файл>, update-psl <файл>, generate password|passphrase")
+		return 2
+	}
+}
+
+// Перевіряє, що тип є у реєстрі, і підказує доступні
+func knownKind(kind string) bool {
+	if _, ok := validatorRegistry[kind]; ok {
+		return true
+	}
+	fmt.Println(Red + "Невідомий тип: " + kind + Reset)
+	fmt.Println("Доступні типи: " + strings.Join(validatorKinds(), ", "))
+	return false
+}
+
+// Перевіряє одне значення: код виходу 0 — валідно, 1 — ні
+func runCheck(kind, value string) int {
+	if !knownKind(kind) {
+		return 2
+	}
+	normalized, valid, errors := runValidator(kind, value)
+	printNormalized(value, normalized)
+	printResult(valid, errors)
+	printHints(kind, normalized)
+	if !valid {
+		return 1
+	}
+	return 0
+}
+
+// Перевіряє кожен непорожній рядок файлу ("-" — стандартний ввід).
+// Рядки, що починаються з '#', пропускаються.
+func runBatch(kind, path string) int {
+	if !knownKind(kind) {
+		return 2
+	}
+
+	input := os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Println(Red+"Помилка відкриття файлу:"+Reset, err)
+			return 2
+		}
+		defer file.Close()
+		input = file
+	}
+
+	total, invalid := 0, 0
+	scanner := bufio.NewScanner(input)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		value := strings.TrimSpace(scanner.Text())
+		if value == "" || strings.HasPrefix(value, "#") {
+			continue
+		}
+		total++
+
+		normalized, valid, errors := runValidator(kind, value)
+		shown := value
+		if normalized != value {
+			shown = value + " -> " + normalized
+		}
+		if valid {
+			fmt.Printf(Green+"%d: OK   "+Reset+"%s\n", lineNo, shown)
+		} else {
+			invalid++
+			fmt.Printf(Red+"%d: FAIL "+Reset+"%s\n", lineNo, shown)
+			for _, e := range errors {
+				fmt.Println(Red + "      - " + e + Reset)
+			}
+		}
+		if suggestion, ok := suggestFor(kind, normalized); ok {
+			fmt.Println(Yellow + "      ? можливо: " + suggestion + Reset)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Println(Red+"Помилка читання:"+Reset, err)
+		return 2
+	}
+
+	fmt.Printf("\nВалідних: %d з %d\n", total-invalid, total)
+	if invalid > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,16 @@
+package main
+
+import "golanghomeworks/validate"
+
+// ---------- Перевірка дат ----------
+// Формати та загальні правила дат — у пакеті validate.
+
+// Правило для дати народження: не в майбутньому і не старше 120 років
+var birthDateRule = validate.DateRule{MaxAge: 120}
+
+// Перевірка дати народження з необов'язковим мінімальним віком
+func validateBirthDate(value string, minAge int) (bool, []string) {
+	rule := birthDateRule
+	rule.MinAge = minAge
+	return validate.Date(value, rule)
+}
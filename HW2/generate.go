@@ -0,0 +1,141 @@
+package main
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// ---------- Генератор паролів та парольних фраз ----------
+// Усі випадкові значення беруться з crypto/rand.
+
+//go:embed data/words_en.txt
+var bundledWordsEN string
+
+//go:embed data/words_uk.txt
+var bundledWordsUK string
+
+// Скільки разів перегенеровувати пароль, якщо він не пройшов валідатор
+const maxGenerateAttempts = 100
+
+// Випадкове число з [0, n)
+func randomIndex(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64()), nil
+}
+
+// Генерує пароль заданої довжини, що містить усі класи символів
+// з правил validatePassword і не містить пробілів.
+// Повертає пароль та його ентропію в бітах.
+func generatePassword(length int) (string, float64, error) {
+	if length < minPasswordLength {
+		return "", 0, fmt.Errorf("довжина пароля має бути не менше %d", minPasswordLength)
+	}
+
+	classes := []string{passwordLower, passwordUpper, passwordDigits, passwordSpecials}
+	alphabet := strings.Join(classes, "")
+
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		password := make([]byte, 0, length)
+
+		// По одному символу з кожного класу, решта — з усього алфавіту
+		for _, class := range classes {
+			i, err := randomIndex(len(class))
+			if err != nil {
+				return "", 0, err
+			}
+			password = append(password, class[i])
+		}
+		for len(password) < length {
+			i, err := randomIndex(len(alphabet))
+			if err != nil {
+				return "", 0, err
+			}
+			password = append(password, alphabet[i])
+		}
+
+		// Перемішування Фішера–Єйтса, щоб обов'язкові символи не стояли на початку
+		for i := len(password) - 1; i > 0; i-- {
+			j, err := randomIndex(i + 1)
+			if err != nil {
+				return "", 0, err
+			}
+			password[i], password[j] = password[j], password[i]
+		}
+
+		if valid, _ := validatePassword(string(password)); valid {
+			entropy := float64(length) * math.Log2(float64(len(alphabet)))
+			return string(password), entropy, nil
+		}
+	}
+	return "", 0, errors.New("не вдалося згенерувати пароль, що відповідає правилам")
+}
+
+// Повертає вбудований список слів для мови "uk" або "en"
+func wordList(lang string) ([]string, error) {
+	var data string
+	switch lang {
+	case "uk":
+		data = bundledWordsUK
+	case "en":
+		data = bundledWordsEN
+	default:
+		return nil, fmt.Errorf("невідома мова списку слів: %s", lang)
+	}
+
+	var words []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words, nil
+}
+
+// Генерує парольну фразу в стилі Diceware з count слів, розділених sep.
+// Повертає фразу та її ентропію в бітах (count * log2(розмір списку)).
+func generatePassphrase(count int, lang, sep string) (string, float64, error) {
+	if count < 1 {
+		return "", 0, errors.New("кількість слів має бути додатною")
+	}
+
+	words, err := wordList(lang)
+	if err != nil {
+		return "", 0, err
+	}
+
+	chosen := make([]string, count)
+	for i := range chosen {
+		j, err := randomIndex(len(words))
+		if err != nil {
+			return "", 0, err
+		}
+		chosen[i] = words[j]
+	}
+
+	entropy := float64(count) * math.Log2(float64(len(words)))
+	return strings.Join(chosen, sep), entropy, nil
+}
+
+// Словесна оцінка ентропії
+func entropyRating(bits float64) string {
+	switch {
+	case bits < 40:
+		return "слабкий"
+	case bits < 60:
+		return "помірний"
+	case bits < 80:
+		return "надійний"
+	default:
+		return "дуже надійний"
+	}
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
-	"regexp"
+	"os"
 	"strconv"
 	"strings"
-	"unicode"
+
+	"golanghomeworks/validate"
 )
 
 // Кольори для консолі (ANSI escape-коди)
@@ -17,7 +19,23 @@ const (
 	Cyan   = "\033[36m"
 )
 
+var reader = bufio.NewReader(os.Stdin)
+
 func main() {
+	if err := validate.LoadDomainLists(); err != nil {
+		fmt.Println(Yellow+"Не вдалося завантажити список доменів верхнього рівня:"+Reset, err)
+	}
+
+	if path := os.Getenv("HW2_DOMAINS"); path != "" {
+		if err := loadPopularDomains(path); err != nil {
+			fmt.Println(Yellow+"Не вдалося завантажити список доменів:"+Reset, err)
+		}
+	}
+
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
 	fmt.Println(Cyan + "===Валідатор даних===" + Reset)
 	fmt.Println("Виберіть опцію:")
 	fmt.Println("1. Перевірка email-адреси")
@@ -25,256 +43,118 @@ func main() {
 	fmt.Println("3. Перевірка телефонного номера")
 	fmt.Println("4. Перевірка IP-адреси")
 	fmt.Println("5. Перевірка URL-адреси")
+	fmt.Println("6. Генерація пароля або парольної фрази")
+	fmt.Println("7. Перевірка дати народження")
+	fmt.Println("8. Перевірка поштового індексу")
+	fmt.Println("9. Розбір та перевірка адреси")
+	fmt.Println("10. Перевірка імені хоста")
+	fmt.Println("11. Перевірка MAC-адреси")
+	fmt.Println("12. Перевірка порту або діапазону портів")
+	fmt.Println("13. Перевірка пари host:port")
+	fmt.Println("14. Перевірка списку поштових скриньок")
 	fmt.Println("0. Вихід")
 
 	var choice int
-	fmt.Print("\nВаш вибір: ")
-	fmt.Scanln(&choice)
+	fmt.Sscan(readLine("\nВаш вибір: "), &choice)
 
 	switch choice {
 
 	//Перевірка email-адреси
 	case 1:
-		var email string
-		fmt.Print("Введіть email-адресу: ")
-		fmt.Scanln(&email)
-		result := true
-		var errors []string
-
-		if strings.Count(email, "@") == 0 {
-			errors = append(errors, "Немає символа '@'")
-		} else if strings.Count(email, "@") > 1 {
-			errors = append(errors, "Більше ніж один символ '@'")
-		}
-		parts := strings.SplitN(email, "@", 2)
-		local := parts[0]
-		domain := parts[1]
-
-		if len(local) < 1 {
-			errors = append(errors, "Порожня локальна частина (до @)")
-		}
-		if len(domain) < 1 {
-			errors = append(errors, "Порожня доменна частина (після @)")
-		}
-		if strings.Contains(email, " ") {
-			errors = append(errors, "У адресі є пробіли")
-		}
-		if !regexp.MustCompile(`^[A-Za-z0-9._-]+$`).MatchString(local) {
-			errors = append(errors, "Недозволені символи") // (у локальній частині)
-		}
-		if !strings.Contains(domain, ".") {
-			errors = append(errors, "У доменній частині немає крапки '.'")
-		} else {
-			parts := strings.Split(domain, ".")
-			tld := parts[len(parts)-1]
-			if len(tld) < 2 || len(tld) > 6 {
-				errors = append(errors, "Після останньої крапки має бути 2–6 символів")
-			}
-		}
-
-		// Вивід результату з кольорами
-		if len(errors) > 0 {
-			result = false
-		}
-
-		if !result {
-			fmt.Println(Red + "Результат: Невалідно! Причини:" + Reset)
-			fmt.Println(Red + strings.Join(errors, "\n") + Reset)
-		} else {
-			fmt.Println(Green + "Результат: Валідно!" + Reset)
-		}
+		email := readLine("Введіть email-адресу: ")
+		normalized := validate.NormalizeEmail(email)
+		printNormalized(email, normalized)
+		printResult(validate.Email(normalized))
+		printHints("email", normalized)
 
 	//Перевірка пароля
 	case 2:
-		var password string
-		fmt.Print("Введіть пароль: ")
-		fmt.Scanln(&password)
-		result := true
-		var errors []string
-
-		if len(password) < 8 {
-			errors = append(errors, "Пароль занадто короткий (мінімум 8 символів)")
-		}
-		if !regexp.MustCompile(`[a-z]`).MatchString(password) {
-			errors = append(errors, "Немає малої літери")
-		}
-		if !regexp.MustCompile(`[A-Z]`).MatchString(password) {
-			errors = append(errors, "Немає великої літери")
-		}
-		if !regexp.MustCompile(`[0-9]`).MatchString(password) {
-			errors = append(errors, "Немає цифри")
-		}
-		if !regexp.MustCompile(`[!@#\$%\^&\*\(\)\-_=+\[\]\{\}\|;:'",.<>/?]`).MatchString(password) {
-			errors = append(errors, "Немає спецсимволу (!@#$%^&*)")
-		}
-		if strings.Contains(password, " ") {
-			errors = append(errors, "Пароль містить пробіли")
-		}
-
-		// Вивід результату з кольорами
-		if len(errors) > 0 {
-			result = false
-		}
-
-		if !result {
-			fmt.Println(Red + "Результат: Невалідно! Причини:" + Reset)
-			fmt.Println(Red + strings.Join(errors, "\n") + Reset)
-		} else {
-			fmt.Println(Green + "Результат: Валідно!" + Reset)
-		}
+		password := readLine("Введіть пароль: ")
+		printResult(validatePasswordWithBreach(password))
 
 	//Перевірка телефонного номера
 	case 3:
-		var phone string
-		fmt.Print("Введіть номер телефону: ")
-		fmt.Scanln(&phone)
-		result := true
-		var errors []string
-		digits := 0
-
-		if !strings.HasPrefix(phone, "+") {
-			errors = append(errors, "Номер має починатися з '+' (міжнародний формат)")
-		}
-
-		for _, ch := range phone {
-			if unicode.IsDigit(ch) {
-				digits++
-			} else if strings.ContainsRune("+-() ", ch) {
-				continue
-			} else {
-				errors = append(errors, fmt.Sprintf("Недозволений символ: %q", ch))
-			}
-		}
-
-		if digits < 10 || digits > 15 {
-			errors = append(errors, "Кількість цифр має бути від 10 до 15")
-		}
-
-		clean := ""
-		for _, ch := range phone {
-			if unicode.IsDigit(ch) {
-				clean += string(ch)
-			}
-		}
-
-		if strings.HasPrefix(phone, "+380") {
-			if len(clean) != 12 {
-				errors = append(errors, "Український номер повинен мати 12 цифр")
-			}
-			operator := clean[3:6]
-			validOperators := []string{"050", "063", "066", "067", "068", "091", "092", "093", "094", "095", "096", "097", "098", "099"}
-			found := false
-			for _, op := range validOperators {
-				if operator == op {
-					found = true
-					break
-				}
-			}
-			if !found {
-				errors = append(errors, "Невідомий код оператора: "+operator)
-			}
-		}
-
-		// Вивід результату з кольорами
-		if len(errors) > 0 {
-			result = false
-		}
-
-		if !result {
-			fmt.Println(Red + "Результат: Невалідно! Причини:" + Reset)
-			fmt.Println(Red + strings.Join(errors, "\n") + Reset)
-		} else {
-			fmt.Println(Green + "Результат: Валідно!" + Reset)
-		}
+		phone := readLine("Введіть номер телефону: ")
+		normalized := validate.NormalizePhone(phone)
+		printNormalized(phone, normalized)
+		printResult(validate.Phone(normalized))
 
 	//Перевірка IP-адреси
 	case 4:
-		var ip string
-		fmt.Print("Введіть IP-адресу: ")
-		fmt.Scanln(&ip)
-		result := true
-		var errors []string
-
-		parts := strings.Split(ip, ".")
-		if len(parts) != 4 {
-			errors = append(errors, "Не відповідає формату X.X.X.X")
-		}
-		if strings.Contains(ip, " ") {
-			errors = append(errors, "IP-адреса містить пробіли")
-		}
-
-		for _, p := range parts {
-			num, err := strconv.Atoi(p)
-			if err != nil {
-				errors = append(errors, "Частина не є числом: "+p)
-				continue
-			}
-			if num < 0 || num > 255 {
-				errors = append(errors, fmt.Sprintf("Частина виходить за межі 0–255: %d", num))
-			}
-		}
-
-		// Вивід результату з кольорами
-		if len(errors) > 0 {
-			result = false
-		}
-
-		if !result {
-			fmt.Println(Red + "Результат: Невалідно! Причини:" + Reset)
-			fmt.Println(Red + strings.Join(errors, "\n") + Reset)
-		} else {
-			fmt.Println(Green + "Результат: Валідно!" + Reset)
-		}
+		ip := readLine("Введіть IP-адресу: ")
+		normalized := validate.NormalizeIP(ip)
+		printNormalized(ip, normalized)
+		printResult(validate.IP(normalized))
 
 	//Перевірка URL-адреси
 	case 5:
-		var url string
-		fmt.Print("Введіть URL: ")
-		fmt.Scanln(&url)
-		result := true
-		var errors []string
-
-		if strings.HasPrefix(url, "http://") {
-			url = strings.TrimPrefix(url, "http://")
-		} else if strings.HasPrefix(url, "https://") {
-			url = strings.TrimPrefix(url, "https://")
-		} else {
-			errors = append(errors, "Відсутній протокол (http:// або https://)")
-		}
-
-		if idx := strings.IndexAny(url, "/?#"); idx != -1 {
-			url = url[:idx]
-		}
-
-		if !strings.Contains(url, ".") {
-			errors = append(errors, "У доменній частині немає крапки '.'")
-		} else {
-			parts := strings.Split(url, ".")
-			tld := parts[len(parts)-1]
-			if len(tld) < 2 || len(tld) > 6 {
-				errors = append(errors, "Після останньої крапки має бути 2–6 символів")
+		url := readLine("Введіть URL: ")
+		normalized := validate.NormalizeURL(url)
+		printNormalized(url, normalized)
+		printResult(validate.URL(normalized))
+		printHints("url", normalized)
+
+	//Генерація пароля або парольної фрази
+	case 6:
+		kind := readLine("Що згенерувати? (1 — пароль, 2 — парольна фраза): ")
+		if strings.TrimSpace(kind) == "2" {
+			count := readIntOrDefault("Кількість слів [6]: ", 6)
+			lang := strings.TrimSpace(readLine("Мова слів (uk/en) [uk]: "))
+			if lang == "" {
+				lang = "uk"
 			}
-		}
-
-		if !regexp.MustCompile(`^[A-Za-z0-9._-]+$`).MatchString(url) {
-			errors = append(errors, "Домен містить недозволені символи")
-		}
-
-		if strings.Contains(url, " ") {
-			errors = append(errors, "Домен містить пробіли")
-		}
-
-		// Вивід результату з кольорами
-		if len(errors) > 0 {
-			result = false
-		}
-
-		if !result {
-			fmt.Println(Red + "Результат: Невалідно! Причини:" + Reset)
-			fmt.Println(Red + strings.Join(errors, "\n") + Reset)
+			printGenerated(generatePassphrase(count, lang, "-"))
 		} else {
-			fmt.Println(Green + "Результат: Валідно!" + Reset)
-		}
+			length := readIntOrDefault("Довжина пароля [16]: ", 16)
+			printGenerated(generatePassword(length))
+		}
+
+	//Перевірка дати народження
+	case 7:
+		date := readLine("Введіть дату народження (ДД.ММ.РРРР): ")
+		minAge := readIntOrDefault("Мінімальний вік [0]: ", 0)
+		printResult(validateBirthDate(date, minAge))
+
+	//Перевірка поштового індексу
+	case 8:
+		code := strings.TrimSpace(readLine("Введіть поштовий індекс: "))
+		country := strings.ToUpper(strings.TrimSpace(readLine("Код країни [UA]: ")))
+		if country == "" {
+			country = "UA"
+		}
+		valid, errors := validatePostalCode(code, country)
+		printResult(valid, errors)
+		if region, ok := uaOblast(code); valid && country == "UA" && ok {
+			fmt.Println(Cyan + "Регіон: " + region + Reset)
+		}
+
+	//Розбір та перевірка адреси
+	case 9:
+		text := readLine("Введіть адресу: ")
+		addr := parseAddress(text)
+		fmt.Println(Cyan + "Місто:    " + addr.City + Reset)
+		fmt.Println(Cyan + "Вулиця:   " + addr.Street + Reset)
+		fmt.Println(Cyan + "Будинок:  " + addr.Building + Reset)
+		fmt.Println(Cyan + "Квартира: " + addr.Apartment + Reset)
+		if addr.PostalCode != "" {
+			fmt.Println(Cyan + "Індекс:   " + addr.PostalCode + Reset)
+		}
+		printResult(validateAddress(text))
+		if missing := addr.Missing(); len(missing) > 0 {
+			fmt.Println(Yellow + "Відсутні частини: " + strings.Join(missing, ", ") + Reset)
+		}
+
+	//Мережеві ідентифікатори
+	case 10:
+		checkInteractive("hostname", "Введіть ім'я хоста: ")
+	case 11:
+		checkInteractive("mac", "Введіть MAC-адресу: ")
+	case 12:
+		checkInteractive("port", "Введіть порт або діапазон (8000-8080): ")
+	case 13:
+		checkInteractive("endpoint", "Введіть host:port або [ipv6]:port: ")
+	case 14:
+		checkInteractive("mailboxes", "Введіть адреси через кому: ")
 
 	case 0:
 		fmt.Println(Yellow + "Вихід із програми." + Reset)
@@ -284,3 +164,72 @@ func main() {
 		fmt.Println(Red + "Невірний вибір опції!" + Reset)
 	}
 }
+
+// Зчитує цілий рядок (разом із пробілами, на відміну від fmt.Scanln)
+func readLine(prompt string) string {
+	fmt.Print(prompt)
+	input, _ := reader.ReadString('\n')
+	return strings.TrimRight(input, "\r\n")
+}
+
+// Зчитує число; порожній ввід означає значення за замовчуванням
+func readIntOrDefault(prompt string, def int) int {
+	input := strings.TrimSpace(readLine(prompt))
+	if input == "" {
+		return def
+	}
+	n, err := strconv.Atoi(input)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// Показує згенерований секрет та його ентропію
+func printGenerated(secret string, entropy float64, err error) {
+	if err != nil {
+		fmt.Println(Red+"Помилка генерації:"+Reset, err)
+		return
+	}
+	fmt.Println(Green + "Згенеровано: " + secret + Reset)
+	fmt.Printf("Ентропія: %.1f біт (%s)\n", entropy, entropyRating(entropy))
+}
+
+// Показує нормалізоване значення, якщо воно відрізняється від введеного
+func printNormalized(original, normalized string) {
+	if original != normalized {
+		fmt.Println(Cyan + "Нормалізовано: " + normalized + Reset)
+	}
+}
+
+// Показує зареєстрований домен (напр. "example.com.ua" для "mail.example.com.ua")
+func printRegistrable(domain string) {
+	if registrable, err := validate.RegistrableDomain(domain); err == nil && registrable != strings.ToLower(domain) {
+		fmt.Println(Cyan + "Зареєстрований домен: " + registrable + Reset)
+	}
+}
+
+// Показує підказки до нормалізованого значення: зареєстрований домен
+// для email та URL і можливе виправлення опечатки в email
+func printHints(kind, normalized string) {
+	switch kind {
+	case "email":
+		if at := strings.LastIndex(normalized, "@"); at != -1 {
+			printRegistrable(normalized[at+1:])
+		}
+	case "url":
+		printRegistrable(validate.URLHost(normalized))
+	}
+	if suggestion, ok := suggestFor(kind, normalized); ok {
+		fmt.Println(Yellow + "Можливо, ви мали на увазі: " + suggestion + Reset)
+	}
+}
+
+// Зчитує значення та перевіряє його валідатором з реєстру
+func checkInteractive(kind, prompt string) {
+	value := readLine(prompt)
+	normalized, valid, errors := runValidator(kind, value)
+	printNormalized(value, normalized)
+	printResult(valid, errors)
+	printHints(kind, normalized)
+}
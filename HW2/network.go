@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golanghomeworks/validate"
+)
+
+// ---------- Мережеві ідентифікатори ----------
+
+var (
+	hostnameLabelRe = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?$`)
+
+	// Поширені записи MAC-адреси: 00:1A:2B:3C:4D:5E, 00-1A-2B-3C-4D-5E,
+	// 001A.2B3C.4D5E (Cisco) та 001A2B3C4D5E без роздільників
+	macPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^([0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}$`),
+		regexp.MustCompile(`^([0-9A-Fa-f]{2}-){5}[0-9A-Fa-f]{2}$`),
+		regexp.MustCompile(`^([0-9A-Fa-f]{4}\.){2}[0-9A-Fa-f]{4}$`),
+		regexp.MustCompile(`^[0-9A-Fa-f]{12}$`),
+	}
+)
+
+// Перевірка імені хоста за RFC 1123: до 253 символів, мітки по 1–63
+// символи з літер, цифр та дефісів, без дефіса на початку чи в кінці.
+func validateHostname(host string) (bool, []string) {
+	var errors []string
+	host = strings.TrimSuffix(host, ".")
+
+	if host == "" {
+		return false, []string{"Порожнє ім'я хоста"}
+	}
+	if len(host) > 253 {
+		errors = append(errors, fmt.Sprintf("Ім'я хоста довше 253 символів (%d)", len(host)))
+	}
+
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		switch {
+		case label == "":
+			errors = append(errors, "Порожня мітка (дві крапки поспіль або крапка на початку)")
+		case len(label) > 63:
+			errors = append(errors, fmt.Sprintf("Мітка довша 63 символів: %s", label))
+		case !hostnameLabelRe.MatchString(label):
+			errors = append(errors, "Мітка містить недозволені символи або дефіс на краю: "+label)
+		}
+	}
+
+	// Повністю числова остання мітка зробила б ім'я невідрізнюваним від IPv4
+	if last := labels[len(labels)-1]; last != "" {
+		if _, err := strconv.Atoi(last); err == nil && len(labels) > 1 {
+			errors = append(errors, "Остання мітка не може складатися лише з цифр")
+		}
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Перевірка MAC-адреси в одному з поширених записів
+func validateMAC(mac string) (bool, []string) {
+	for _, re := range macPatterns {
+		if re.MatchString(mac) {
+			return true, nil
+		}
+	}
+	return false, []string{"Не відповідає жодному формату MAC (00:1A:2B:3C:4D:5E, 00-1A-..., 001A.2B3C.4D5E, 001A2B3C4D5E)"}
+}
+
+// Приводить MAC-адресу до вигляду 00:1a:2b:3c:4d:5e
+func normalizeMAC(mac string) string {
+	mac = strings.TrimSpace(mac)
+	if ok, _ := validateMAC(mac); !ok {
+		return mac
+	}
+
+	hex := strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac))
+	pairs := make([]string, 0, 6)
+	for i := 0; i < len(hex); i += 2 {
+		pairs = append(pairs, hex[i:i+2])
+	}
+	return strings.Join(pairs, ":")
+}
+
+// Розбирає номер порту 1–65535
+func parsePort(s string) (int, []string) {
+	port, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, []string{"Порт не є числом: " + s}
+	}
+	if port < 1 || port > 65535 {
+		return 0, []string{fmt.Sprintf("Порт виходить за межі 1–65535: %d", port)}
+	}
+	return port, nil
+}
+
+// Перевірка порту або діапазону портів ("8080" чи "8000-8080")
+func validatePort(value string) (bool, []string) {
+	from, to, isRange := strings.Cut(value, "-")
+	if !isRange {
+		_, errors := parsePort(from)
+		return len(errors) == 0, errors
+	}
+
+	start, errors := parsePort(from)
+	end, errs := parsePort(to)
+	errors = append(errors, errs...)
+	if len(errors) == 0 && start > end {
+		errors = append(errors, fmt.Sprintf("Початок діапазону більший за кінець: %d > %d", start, end))
+	}
+	return len(errors) == 0, errors
+}
+
+// Перевірка пари host:port або [ipv6]:port
+func validateEndpoint(endpoint string) (bool, []string) {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		if strings.Count(endpoint, ":") > 1 && !strings.HasPrefix(endpoint, "[") {
+			return false, []string{"IPv6-адресу потрібно взяти у квадратні дужки: [::1]:8080"}
+		}
+		return false, []string{"Очікується формат host:port або [ipv6]:port"}
+	}
+
+	_, errors := parsePort(port)
+
+	switch {
+	case strings.HasPrefix(endpoint, "["):
+		if ip := net.ParseIP(host); ip == nil || ip.To4() != nil {
+			errors = append(errors, "Некоректна IPv6-адреса: "+host)
+		}
+	case regexp.MustCompile(`^[0-9.]+$`).MatchString(host):
+		_, errs := validate.IP(host)
+		errors = append(errors, errs...)
+	default:
+		_, errs := validateHostname(host)
+		errors = append(errors, errs...)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Розбиває список адрес за ',' або ';', не розриваючи лапки та <...>
+func splitMailboxes(list string) []string {
+	var parts []string
+	var current strings.Builder
+	inQuotes, inAngle := false, false
+
+	for _, ch := range list {
+		switch {
+		case ch == '"':
+			inQuotes = !inQuotes
+		case ch == '<' && !inQuotes:
+			inAngle = true
+		case ch == '>' && !inQuotes:
+			inAngle = false
+		case (ch == ',' || ch == ';') && !inQuotes && !inAngle:
+			parts = append(parts, strings.TrimSpace(current.String()))
+			current.Reset()
+			continue
+		}
+		current.WriteRune(ch)
+	}
+	parts = append(parts, strings.TrimSpace(current.String()))
+	return parts
+}
+
+// Виділяє адресу з запису "Ім'я <user@example.com>" або повертає запис як є
+func mailboxAddress(mailbox string) (string, bool) {
+	open := strings.LastIndex(mailbox, "<")
+	if open == -1 {
+		return mailbox, true
+	}
+	if !strings.HasSuffix(mailbox, ">") {
+		return "", false
+	}
+	return strings.TrimSpace(mailbox[open+1 : len(mailbox)-1]), true
+}
+
+// Перевірка списку поштових скриньок у стилі заголовків листа:
+// "Іван <ivan@ukr.net>, olena@gmail.com; "Відділ, продажі" <sales@example.com>"
+func validateMailboxList(list string) (bool, []string) {
+	var errors []string
+
+	for i, mailbox := range splitMailboxes(list) {
+		if mailbox == "" {
+			errors = append(errors, fmt.Sprintf("#%d: порожній запис", i+1))
+			continue
+		}
+		address, ok := mailboxAddress(mailbox)
+		if !ok {
+			errors = append(errors, fmt.Sprintf("#%d: незакрита дужка '<' у %q", i+1, mailbox))
+			continue
+		}
+		if _, errs := validate.Email(address); len(errs) > 0 {
+			for _, e := range errs {
+				errors = append(errors, fmt.Sprintf("#%d (%s): %s", i+1, address, e))
+			}
+		}
+	}
+
+	return len(errors) == 0, errors
+}
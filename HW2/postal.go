@@ -0,0 +1,94 @@
+package main
+
+import (
+	_ "embed"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ---------- Поштові індекси ----------
+
+//go:embed data/ua_postal.txt
+var bundledUAPostal string
+
+// Шаблони поштових індексів за кодом країни (ISO 3166-1 alpha-2)
+var postalPatterns = map[string]*regexp.Regexp{
+	"UA": regexp.MustCompile(`^\d{5}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"MD": regexp.MustCompile(`^(MD-?)?\d{4}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"CA": regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+}
+
+// Діапазон перших двох цифр індексу та відповідна область
+type postalRange struct {
+	From, To int
+	Region   string
+}
+
+var uaPostalRanges = parseUAPostal(bundledUAPostal)
+
+// Формат рядка: "01-06 м. Київ"
+func parseUAPostal(data string) []postalRange {
+	var ranges []postalRange
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		span, region, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		fromStr, toStr, _ := strings.Cut(span, "-")
+		from, err1 := strconv.Atoi(fromStr)
+		to, err2 := strconv.Atoi(toStr)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		ranges = append(ranges, postalRange{From: from, To: to, Region: strings.TrimSpace(region)})
+	}
+	return ranges
+}
+
+// Повертає область за українським індексом
+func uaOblast(code string) (string, bool) {
+	if len(code) < 2 {
+		return "", false
+	}
+	prefix, err := strconv.Atoi(code[:2])
+	if err != nil {
+		return "", false
+	}
+	for _, r := range uaPostalRanges {
+		if prefix >= r.From && prefix <= r.To {
+			return r.Region, true
+		}
+	}
+	return "", false
+}
+
+// Перевірка поштового індексу для країни
+func validatePostalCode(code, country string) (bool, []string) {
+	var errors []string
+	code = strings.ToUpper(strings.TrimSpace(code))
+	country = strings.ToUpper(strings.TrimSpace(country))
+
+	pattern, ok := postalPatterns[country]
+	if !ok {
+		return false, []string{"Невідомий код країни: " + country}
+	}
+	if !pattern.MatchString(code) {
+		errors = append(errors, "Індекс не відповідає формату країни "+country)
+	} else if country == "UA" {
+		if _, ok := uaOblast(code); !ok {
+			errors = append(errors, "Індекс не належить жодній області України: "+code)
+		}
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"golanghomeworks/validate"
+)
+
+// ---------- Реєстр валідаторів ----------
+// Один опис на кожен тип даних, щоб меню, командний рядок та пакетний
+// режим використовували однакові нормалізацію та перевірку.
+
+type validatorEntry struct {
+	Title     string
+	Normalize func(string) string
+	Validate  func(string) (bool, []string)
+}
+
+var validatorRegistry = map[string]validatorEntry{
+	"email":     {"Email-адреса", validate.NormalizeEmail, validate.Email},
+	"password":  {"Пароль", nil, validatePasswordWithBreach},
+	"phone":     {"Телефонний номер", validate.NormalizePhone, validate.Phone},
+	"ip":        {"IP-адреса", validate.NormalizeIP, validate.IP},
+	"url":       {"URL-адреса", validate.NormalizeURL, validate.URL},
+	"date":      {"Дата народження", strings.TrimSpace, func(v string) (bool, []string) { return validateBirthDate(v, 0) }},
+	"postal":    {"Поштовий індекс (UA)", strings.TrimSpace, func(v string) (bool, []string) { return validatePostalCode(v, "UA") }},
+	"address":   {"Адреса", strings.TrimSpace, validateAddress},
+	"hostname":  {"Ім'я хоста", normalizeHostname, validateHostname},
+	"mac":       {"MAC-адреса", normalizeMAC, validateMAC},
+	"port":      {"Порт або діапазон портів", strings.TrimSpace, validatePort},
+	"endpoint":  {"Пара host:port", strings.TrimSpace, validateEndpoint},
+	"mailboxes": {"Список поштових скриньок", strings.TrimSpace, validateMailboxList},
+}
+
+// Перевірка пароля разом із пошуком у витоках
+func validatePasswordWithBreach(password string) (bool, []string) {
+	_, errors := validatePassword(password)
+	errors = append(errors, checkPasswordBreach(password)...)
+	return len(errors) == 0, errors
+}
+
+// Ім'я хоста нечутливе до регістру
+func normalizeHostname(host string) string {
+	return strings.ToLower(strings.TrimSpace(host))
+}
+
+// Нормалізує та перевіряє значення вказаного типу
+func runValidator(kind, value string) (normalized string, valid bool, errors []string) {
+	entry := validatorRegistry[kind]
+	normalized = value
+	if entry.Normalize != nil {
+		normalized = entry.Normalize(value)
+	}
+	valid, errors = entry.Validate(normalized)
+	return normalized, valid, errors
+}
+
+// Відсортований список доступних типів
+func validatorKinds() []string {
+	kinds := make([]string, 0, len(validatorRegistry))
+	for kind := range validatorRegistry {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
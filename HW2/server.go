@@ -0,0 +1,280 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ---------- HTTP-сервіс валідації ----------
+// POST /validate/{kind}  {"value": "..."}                  — одне значення
+// POST /bulk             {"items": [{"kind", "value"}...]} — кілька значень
+// GET  /kinds                                              — доступні типи
+// Відповіді містять ті самі причини, що й консоль.
+
+const (
+	maxBodyBytes    = 64 << 10 // 64 КБ на запит
+	maxBulkItems    = 100
+	defaultRate     = 5  // запитів на секунду на клієнта
+	defaultBurst    = 20 // запас для короткочасних сплесків
+	limiterIdleTime = 10 * time.Minute
+)
+
+type validateRequest struct {
+	Value string `json:"value"`
+}
+
+type bulkItem struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type bulkRequest struct {
+	Items []bulkItem `json:"items"`
+}
+
+type validateResponse struct {
+	Kind       string   `json:"kind"`
+	Value      string   `json:"value"`
+	Normalized string   `json:"normalized"`
+	Valid      bool     `json:"valid"`
+	Errors     []string `json:"errors"`
+	Suggestion string   `json:"suggestion,omitempty"`
+}
+
+type bulkResponse struct {
+	Results []validateResponse `json:"results"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type kindInfo struct {
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+}
+
+// ---- Обмеження частоти (token bucket на кожного клієнта) ----
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	clients   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter(rate, burst float64) *rateLimiter {
+	return &rateLimiter{
+		rate:    rate,
+		burst:   burst,
+		clients: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Забирає один токен клієнта. Якщо токенів немає, повертає час очікування.
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.clients[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastSeen: now}
+		l.clients[client] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.lastSeen).Seconds()*l.rate)
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// Прибирає клієнтів, які давно не заходили, щоб мапа не росла без меж.
+// Обхід мапи виконується не частіше ніж раз на limiterIdleTime, тож
+// окремий запит не платить за кількість клієнтів. Викликається під l.mu.
+func (l *rateLimiter) sweep(now time.Time) {
+	if l.lastSweep.IsZero() {
+		l.lastSweep = now
+	}
+	if now.Sub(l.lastSweep) < limiterIdleTime {
+		return
+	}
+	for key, b := range l.clients {
+		if now.Sub(b.lastSeen) > limiterIdleTime {
+			delete(l.clients, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Ідентифікатор клієнта — IP-адреса без порту
+func clientKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (l *rateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, wait := l.allow(clientKey(r)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeJSON(w, http.StatusTooManyRequests, errorResponse{"Забагато запитів, спробуйте пізніше"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ---- Обробники ----
+
+// Створює обробник сервісу з заданим обмежувачем частоти
+func newServer(limiter *rateLimiter) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /validate/{kind}", handleValidate)
+	mux.HandleFunc("POST /bulk", handleBulk)
+	mux.HandleFunc("GET /kinds", handleKinds)
+	return limiter.middleware(mux)
+}
+
+func handleValidate(w http.ResponseWriter, r *http.Request) {
+	kind := r.PathValue("kind")
+	if _, ok := validatorRegistry[kind]; !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{"Невідомий тип: " + kind})
+		return
+	}
+
+	var req validateRequest
+	if status, err := decodeBody(w, r, &req); err != nil {
+		writeJSON(w, status, errorResponse{err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, validateOne(kind, req.Value))
+}
+
+func handleBulk(w http.ResponseWriter, r *http.Request) {
+	var req bulkRequest
+	if status, err := decodeBody(w, r, &req); err != nil {
+		writeJSON(w, status, errorResponse{err.Error()})
+		return
+	}
+	if len(req.Items) > maxBulkItems {
+		writeJSON(w, http.StatusRequestEntityTooLarge,
+			errorResponse{fmt.Sprintf("Забагато елементів: %d (максимум %d)", len(req.Items), maxBulkItems)})
+		return
+	}
+
+	resp := bulkResponse{Results: make([]validateResponse, 0, len(req.Items))}
+	for _, item := range req.Items {
+		if _, ok := validatorRegistry[item.Kind]; !ok {
+			resp.Results = append(resp.Results, validateResponse{
+				Kind:   item.Kind,
+				Value:  item.Value,
+				Errors: []string{"Невідомий тип: " + item.Kind},
+			})
+			continue
+		}
+		resp.Results = append(resp.Results, validateOne(item.Kind, item.Value))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func handleKinds(w http.ResponseWriter, r *http.Request) {
+	kinds := make([]kindInfo, 0, len(validatorRegistry))
+	for _, kind := range validatorKinds() {
+		kinds = append(kinds, kindInfo{Kind: kind, Title: validatorRegistry[kind].Title})
+	}
+	writeJSON(w, http.StatusOK, kinds)
+}
+
+func validateOne(kind, value string) validateResponse {
+	normalized, valid, errors := runValidator(kind, value)
+	if errors == nil {
+		errors = []string{}
+	}
+	// Пароль не повертаємо у відповіді, щоб він не осідав у логах та кешах
+	if kind == "password" {
+		value, normalized = "", ""
+	}
+	resp := validateResponse{
+		Kind:       kind,
+		Value:      value,
+		Normalized: normalized,
+		Valid:      valid,
+		Errors:     errors,
+	}
+	resp.Suggestion, _ = suggestFor(kind, normalized)
+	return resp
+}
+
+// Читає JSON-тіло з обмеженням розміру. Повертає HTTP-статус для помилки.
+func decodeBody(w http.ResponseWriter, r *http.Request, dst any) (int, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return http.StatusRequestEntityTooLarge, fmt.Errorf("тіло запиту більше за %d байт", maxBodyBytes)
+		}
+		return http.StatusBadRequest, fmt.Errorf("некоректний JSON: %v", err)
+	}
+
+	// Після об'єкта може бути лише кінець тіла. Читання хвоста також
+	// перевіряє ліміт розміру, якщо перший об'єкт малий, а хвіст — ні.
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return http.StatusRequestEntityTooLarge, fmt.Errorf("тіло запиту більше за %d байт", maxBodyBytes)
+		}
+		return http.StatusBadRequest, errors.New("некоректний JSON: після об'єкта є зайві дані")
+	}
+	return http.StatusOK, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(v)
+}
+
+// Запускає сервіс на адресі addr
+func serve(addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           newServer(newRateLimiter(defaultRate, defaultBurst)),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+	fmt.Println(Cyan + "Сервіс валідації слухає " + addr + Reset)
+	return server.ListenAndServe()
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golanghomeworks/validate"
+)
+
+func init() {
+	if err := validate.LoadDomainLists(); err != nil {
+		panic(err)
+	}
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestValidateEmail(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	rec := doRequest(t, handler, http.MethodPost, "/validate/email", `{"value": " user@UKR.NET "}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", rec.Code, rec.Body)
+	}
+
+	var resp validateResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Valid || resp.Normalized != "user@ukr.net" || len(resp.Errors) != 0 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestValidateReturnsConsoleReasons(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	rec := doRequest(t, handler, http.MethodPost, "/validate/ip", `{"value": "300.1.1"}`)
+	var resp validateResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+
+	_, want := validate.IP("300.1.1")
+	if resp.Valid || strings.Join(resp.Errors, "\n") != strings.Join(want, "\n") {
+		t.Errorf("errors = %q, want %q", resp.Errors, want)
+	}
+}
+
+func TestValidateUnknownKind(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	rec := doRequest(t, handler, http.MethodPost, "/validate/nope", `{"value": "x"}`)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestValidateBadJSON(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	for _, body := range []string{`{"value":`, `{"val": "x"}`, ``} {
+		rec := doRequest(t, handler, http.MethodPost, "/validate/email", body)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("body %q: status = %d, want 400", body, rec.Code)
+		}
+	}
+}
+
+func TestValidateMethodNotAllowed(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	rec := doRequest(t, handler, http.MethodGet, "/validate/email", "")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want 405", rec.Code)
+	}
+}
+
+func TestBodyTooLarge(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	body := `{"value": "` + strings.Repeat("a", maxBodyBytes) + `"}`
+	rec := doRequest(t, handler, http.MethodPost, "/validate/email", body)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestBulk(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	body := `{"items": [
+		{"kind": "email", "value": "a@example.com"},
+		{"kind": "port", "value": "70000"},
+		{"kind": "nope", "value": "x"}
+	]}`
+	rec := doRequest(t, handler, http.MethodPost, "/bulk", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", rec.Code, rec.Body)
+	}
+
+	var resp bulkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(resp.Results))
+	}
+	if !resp.Results[0].Valid || resp.Results[1].Valid || resp.Results[2].Valid {
+		t.Errorf("unexpected validity: %+v", resp.Results)
+	}
+}
+
+func TestBulkTooManyItems(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	items := strings.Repeat(`{"kind": "ip", "value": "1.1.1.1"},`, maxBulkItems)
+	rec := doRequest(t, handler, http.MethodPost, "/bulk", `{"items": [`+items+`{"kind": "ip", "value": "1.1.1.1"}]}`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestRateLimitPerClient(t *testing.T) {
+	limiter := newRateLimiter(1, 2)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	handler := newServer(limiter)
+
+	request := func(addr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/kinds", nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := request("10.0.0.1:1111"); code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i, code)
+		}
+	}
+	if code := request("10.0.0.1:2222"); code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429 after burst", code)
+	}
+	if code := request("10.0.0.2:1111"); code != http.StatusOK {
+		t.Errorf("other client: status = %d, want 200", code)
+	}
+
+	now = now.Add(time.Second)
+	if code := request("10.0.0.1:1111"); code != http.StatusOK {
+		t.Errorf("after refill: status = %d, want 200", code)
+	}
+}
+
+func TestValidateTrailingData(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	for _, body := range []string{`{"value": "a@example.com"} garbage`, `{"value": "a@example.com"}{"value": "b"}`} {
+		rec := doRequest(t, handler, http.MethodPost, "/validate/email", body)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("body %q: status = %d, want 400", body, rec.Code)
+		}
+	}
+
+	// Пробіли та перевід рядка після об'єкта — це ще кінець тіла
+	rec := doRequest(t, handler, http.MethodPost, "/validate/email", "{\"value\": \"a@example.com\"}\n  ")
+	if rec.Code != http.StatusOK {
+		t.Errorf("trailing whitespace: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestBodyTooLargeAfterSmallObject(t *testing.T) {
+	handler := newServer(newRateLimiter(100, 100))
+
+	body := `{"value": "a@example.com"}` + strings.Repeat(" ", maxBodyBytes)
+	rec := doRequest(t, handler, http.MethodPost, "/validate/email", body)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestRateLimiterSweepsIdleClients(t *testing.T) {
+	limiter := newRateLimiter(1, 2)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 50; i++ {
+		limiter.allow(fmt.Sprintf("10.0.0.%d", i))
+	}
+	now = now.Add(limiterIdleTime / 2)
+	limiter.allow("10.0.1.1")
+	if len(limiter.clients) != 51 {
+		t.Fatalf("clients = %d before sweep, want 51", len(limiter.clients))
+	}
+
+	// Через limiterIdleTime після попереднього обходу давні клієнти зникають
+	now = now.Add(limiterIdleTime/2 + time.Second)
+	limiter.allow("10.0.1.2")
+	if _, ok := limiter.clients["10.0.0.1"]; ok || len(limiter.clients) != 2 {
+		t.Errorf("clients after sweep = %d, want 2 (10.0.1.1 and 10.0.1.2)", len(limiter.clients))
+	}
+}
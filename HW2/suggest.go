@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// ---------- Підказки для опечаток ----------
+
+// Популярні поштові домени, з якими порівнюється введений домен.
+// Список можна замінити файлом (один домен на рядок) через змінну
+// середовища HW2_DOMAINS.
+var popularDomains = []string{
+	"gmail.com",
+	"ukr.net",
+	"i.ua",
+	"meta.ua",
+	"outlook.com",
+	"hotmail.com",
+	"yahoo.com",
+	"icloud.com",
+	"proton.me",
+	"protonmail.com",
+}
+
+// Максимальна відстань редагування, за якої домен вважається опечаткою.
+const maxSuggestDistance = 2
+
+// Завантажує список популярних доменів з файлу.
+// Порожні рядки та рядки, що починаються з '#', пропускаються.
+func loadPopularDomains(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	popularDomains = domains
+	return nil
+}
+
+// Відстань редагування (Дамерау–Левенштейна в варіанті optimal string
+// alignment): вставка, видалення, заміна та перестановка сусідніх символів
+// коштують по 1. Завдяки перестановкам "gmial" -> "gmail" дає відстань 1.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n, m := len(ra), len(rb)
+
+	d := make([][]int, n+1)
+	for i := range d {
+		d[i] = make([]int, m+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[n][m]
+}
+
+// Шукає найближчий популярний домен. Якщо домен уже є у списку
+// або жоден не підходить, повертає false.
+func suggestDomain(domain string) (string, bool) {
+	domain = strings.ToLower(domain)
+
+	// Для коротких доменів дозволяємо лише одну правку, інакше
+	// "a.ua" чи "x.com" почнуть "виправлятися" на що завгодно.
+	limit := maxSuggestDistance
+	if len(domain) < 6 {
+		limit = 1
+	}
+	best, bestDist := "", limit+1
+
+	for _, candidate := range popularDomains {
+		if candidate == domain {
+			return "", false
+		}
+		dist := editDistance(domain, candidate)
+		if dist < bestDist {
+			best, bestDist = candidate, dist
+		}
+	}
+
+	if best == "" {
+		return "", false
+	}
+	return best, true
+}
+
+// Пропонує виправлену email-адресу, якщо домен схожий на популярний.
+func suggestEmail(email string) (string, bool) {
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return "", false
+	}
+	domain, ok := suggestDomain(email[at+1:])
+	if !ok {
+		return "", false
+	}
+	return email[:at+1] + domain, true
+}
+
+// Підказка для значення будь-якого типу з реєстру. Поки що підказки є
+// лише для email; для інших типів повертає false.
+func suggestFor(kind, normalized string) (string, bool) {
+	if kind == "email" {
+		return suggestEmail(normalized)
+	}
+	return "", false
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ---------- Валідатори ----------
+// Кожен валідатор повертає ознаку валідності та список причин помилок.
+// Email, телефон, IP та URL перевіряє спільний пакет validate.
+
+// Правила для пароля (використовуються також генератором)
+const (
+	minPasswordLength = 8
+	passwordLower     = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpper     = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits    = "0123456789"
+	passwordSpecials  = "!@#$%^&*()-_=+[]{}|;:'\",.<>/?" // той самий набір, що й у регулярному виразі нижче
+)
+
+// Перевірка надійності пароля
+func validatePassword(password string) (bool, []string) {
+	var errors []string
+
+	if len(password) < minPasswordLength {
+		errors = append(errors, fmt.Sprintf("Пароль занадто короткий (мінімум %d символів)", minPasswordLength))
+	}
+	if !regexp.MustCompile(`[a-z]`).MatchString(password) {
+		errors = append(errors, "Немає малої літери")
+	}
+	if !regexp.MustCompile(`[A-Z]`).MatchString(password) {
+		errors = append(errors, "Немає великої літери")
+	}
+	if !regexp.MustCompile(`[0-9]`).MatchString(password) {
+		errors = append(errors, "Немає цифри")
+	}
+	if !regexp.MustCompile(`[!@#\$%\^&\*\(\)\-_=+\[\]\{\}\|;:'",.<>/?]`).MatchString(password) {
+		errors = append(errors, "Немає спецсимволу (!@#$%^&*)")
+	}
+	if strings.Contains(password, " ") {
+		errors = append(errors, "Пароль містить пробіли")
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Вивід результату з кольорами
+func printResult(valid bool, errors []string) {
+	if !valid {
+		fmt.Println(Red + "Результат: Невалідно! Причини:" + Reset)
+		fmt.Println(Red + strings.Join(errors, "\n") + Reset)
+	} else {
+		fmt.Println(Green + "Результат: Валідно!" + Reset)
+	}
+}
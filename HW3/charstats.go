@@ -0,0 +1,276 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------- Статистика символів ----------
+// Частоти літер (гістограма), склад за письмом (кирилиця, латиниця, цифри,
+// інше), частка голосних і великих літер (рахується до зведення до нижнього
+// регістру) та найчастіші пари літер усередині слів.
+//
+// Ознаки проблемного тексту:
+// - слова, у яких змішані кирилиця й латиниця ("кiт" з латинською i) —
+//   типовий слід неправильної розкладки клавіатури;
+// - символ заміни U+FFFD і недійсні байти UTF-8 — текст у іншому кодуванні;
+// - багато літер "Ð", "Ñ", "Ã", "Â" — UTF-8, прочитаний як Latin-1/cp1252.
+
+const (
+	vowelsEN       = "aeiou"
+	histogramWidth = 40
+	charPairsTop   = 15
+	mixedShown     = 10 // скільки слів зі змішаним письмом показувати
+	mojibakeShare  = 0.05
+)
+
+// Слово, в якому змішані кирилиця й латиниця
+type mixedWord struct {
+	Raw  string
+	Line int
+	Col  int
+}
+
+// Лічильники символів
+type charStats struct {
+	Letters   map[rune]int   // літери в нижньому регістрі
+	Pairs     map[string]int // пари сусідніх літер у словах
+	Cyrillic  int
+	Latin     int
+	Digits    int
+	Other     int // інші непробільні символи (розділові знаки, інші письма)
+	Spaces    int
+	Vowels    int
+	Consonant int
+	Upper     int
+	Lower     int
+	Invalid   int // U+FFFD та недійсні байти
+	Mojibake  int // Ð, Ñ, Ã, Â
+	Mixed     []mixedWord
+}
+
+func newCharStats() charStats {
+	return charStats{Letters: make(map[rune]int), Pairs: make(map[string]int)}
+}
+
+func (c *charStats) addLine(line string, tokens []token) {
+	var prev rune
+	for _, r := range strings.TrimRight(line, "\r\n") {
+		switch {
+		case r == utf8.RuneError:
+			c.Invalid++
+		case unicode.IsSpace(r):
+			c.Spaces++
+		case unicode.IsDigit(r):
+			c.Digits++
+		case unicode.IsLetter(r):
+			c.addLetter(r)
+		default:
+			c.Other++
+		}
+		if unicode.IsLetter(prev) && unicode.IsLetter(r) {
+			c.Pairs[string([]rune{unicode.ToLower(prev), unicode.ToLower(r)})]++
+		}
+		prev = r
+	}
+
+	for _, t := range tokens {
+		if t.Kind == tokenWord && isMixedScript(t.Raw) {
+			c.Mixed = append(c.Mixed, mixedWord{Raw: t.Raw, Line: t.Line, Col: t.Col})
+		}
+	}
+}
+
+func (c *charStats) addLetter(r rune) {
+	if unicode.IsUpper(r) {
+		c.Upper++
+	} else if unicode.IsLower(r) {
+		c.Lower++
+	}
+	lower := unicode.ToLower(r)
+	c.Letters[lower]++
+
+	switch {
+	case unicode.Is(unicode.Cyrillic, r):
+		c.Cyrillic++
+		if isVowelUK(lower) {
+			c.Vowels++
+		} else if lower != 'ь' {
+			c.Consonant++
+		}
+	case unicode.Is(unicode.Latin, r):
+		c.Latin++
+		if strings.ContainsRune("ðñãâ", lower) {
+			c.Mojibake++
+		}
+		if lower >= 'a' && lower <= 'z' {
+			if strings.ContainsRune(vowelsEN, lower) {
+				c.Vowels++
+			} else {
+				c.Consonant++
+			}
+		}
+	default:
+		c.Other++
+	}
+}
+
+func isMixedScript(word string) bool {
+	cyr, lat := false, false
+	for _, r := range word {
+		cyr = cyr || unicode.Is(unicode.Cyrillic, r)
+		lat = lat || unicode.Is(unicode.Latin, r)
+	}
+	return cyr && lat
+}
+
+func (c *charStats) merge(other charStats) {
+	if other.Letters == nil {
+		return
+	}
+	if c.Letters == nil {
+		*c = newCharStats()
+	}
+	for r, n := range other.Letters {
+		c.Letters[r] += n
+	}
+	for p, n := range other.Pairs {
+		c.Pairs[p] += n
+	}
+	c.Cyrillic += other.Cyrillic
+	c.Latin += other.Latin
+	c.Digits += other.Digits
+	c.Other += other.Other
+	c.Spaces += other.Spaces
+	c.Vowels += other.Vowels
+	c.Consonant += other.Consonant
+	c.Upper += other.Upper
+	c.Lower += other.Lower
+	c.Invalid += other.Invalid
+	c.Mojibake += other.Mojibake
+	c.Mixed = append(c.Mixed, other.Mixed...)
+}
+
+// Смуга гістограми з точністю до 1/8 символу
+func histogramBar(value, maxValue, width int) string {
+	if maxValue == 0 {
+		return ""
+	}
+	eighths := value * width * 8 / maxValue
+	bar := strings.Repeat("█", eighths/8)
+	if rest := eighths % 8; rest > 0 {
+		bar += string([]rune("▏▎▍▌▋▊▉")[rest-1])
+	}
+	return bar
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
+
+// Виводить статистику символів
+func writeCharStats(w io.Writer, name string, c charStats) {
+	fmt.Fprintf(w, "\n--- Символи: %s ---\n", name)
+	total := c.Cyrillic + c.Latin + c.Digits + c.Other
+	if total == 0 {
+		fmt.Fprintln(w, "Непробільних символів немає.")
+		return
+	}
+
+	fmt.Fprintf(w, "Непробільних символів: %d, пробільних: %d\n", total, c.Spaces)
+	fmt.Fprintln(w, "Письмо:")
+	for _, s := range []struct {
+		name  string
+		count int
+	}{
+		{"кирилиця", c.Cyrillic},
+		{"латиниця", c.Latin},
+		{"цифри", c.Digits},
+		{"інше", c.Other},
+	} {
+		fmt.Fprintf(w, "  %-9s %8d  %5.1f%%  %s\n", s.name, s.count, percent(s.count, total),
+			histogramBar(s.count, total, histogramWidth/2))
+	}
+	fmt.Fprintf(w, "Голосні / приголосні: %d / %d (%.1f%% голосних)\n",
+		c.Vowels, c.Consonant, percent(c.Vowels, c.Vowels+c.Consonant))
+	fmt.Fprintf(w, "Великі літери: %d з %d (%.1f%%)\n", c.Upper, c.Upper+c.Lower, percent(c.Upper, c.Upper+c.Lower))
+
+	// Гістограма частот літер
+	list := make([]rune, 0, len(c.Letters))
+	allLetters, maxCount := 0, 0
+	for r, n := range c.Letters {
+		list = append(list, r)
+		allLetters += n
+		maxCount = max(maxCount, n)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if c.Letters[list[i]] != c.Letters[list[j]] {
+			return c.Letters[list[i]] > c.Letters[list[j]]
+		}
+		return list[i] < list[j]
+	})
+	fmt.Fprintln(w, "\nЧастоти літер:")
+	for _, r := range list {
+		n := c.Letters[r]
+		// Латинська i та кирилична і виглядають однаково — підписуємо письмо
+		tag := ""
+		if c.Cyrillic > 0 && c.Latin > 0 {
+			tag = " кир"
+			if unicode.Is(unicode.Latin, r) {
+				tag = " лат"
+			}
+		}
+		fmt.Fprintf(w, "  %c%s %8d  %5.2f%%  %s\n", r, tag, n, percent(n, allLetters), histogramBar(n, maxCount, histogramWidth))
+	}
+
+	// Найчастіші пари літер
+	pairs := make([]string, 0, len(c.Pairs))
+	for p := range c.Pairs {
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if c.Pairs[pairs[i]] != c.Pairs[pairs[j]] {
+			return c.Pairs[pairs[i]] > c.Pairs[pairs[j]]
+		}
+		return pairs[i] < pairs[j]
+	})
+	if len(pairs) > charPairsTop {
+		pairs = pairs[:charPairsTop]
+	}
+	if len(pairs) > 0 {
+		fmt.Fprintln(w, "\nНайчастіші пари літер:")
+		for _, p := range pairs {
+			fmt.Fprintf(w, "  %s %8d\n", p, c.Pairs[p])
+		}
+	}
+
+	writeCharWarnings(w, c, allLetters)
+}
+
+// Попередження про змішану розкладку та неправильне кодування
+func writeCharWarnings(w io.Writer, c charStats, letters int) {
+	if len(c.Mixed) > 0 {
+		fmt.Fprintf(w, "\nУвага: %d слів(а) змішують кирилицю й латиницю — можливо, неправильна розкладка:\n", len(c.Mixed))
+		for i, m := range c.Mixed {
+			if i == mixedShown {
+				fmt.Fprintf(w, "  … ще %d\n", len(c.Mixed)-mixedShown)
+				break
+			}
+			fmt.Fprintf(w, "  %d:%d  %s\n", m.Line, m.Col, m.Raw)
+		}
+	}
+	if c.Invalid > 0 {
+		fmt.Fprintf(w, "\nУвага: %d пошкоджених символів (�) — текст, імовірно, не в UTF-8.\n", c.Invalid)
+	}
+	if letters > 0 && float64(c.Mojibake)/float64(letters) > mojibakeShare {
+		fmt.Fprintf(w, "\nУвага: багато літер Ð, Ñ, Ã, Â (%.1f%%) — схоже на UTF-8, прочитаний як Latin-1.\n",
+			percent(c.Mojibake, letters))
+	}
+}
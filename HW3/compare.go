@@ -0,0 +1,428 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"strings"
+)
+
+// ---------- Порівняння документів ----------
+// Міри схожості (від 0 — нічого спільного до 1 — однакові):
+// - Jaccard — частка спільних слів серед усіх різних слів двох текстів;
+// - косинус TF-IDF — враховує частоту слів і знижує вагу тих, що є в усіх
+//   документах; IDF згладжений (log((1+N)/(1+df)) + 1), інакше для двох
+//   документів спільні слова мали б нульову вагу;
+// - MinHash — оцінка Jaccard для множин шинглів (послідовностей із
+//   shingleSize слів): чутлива до порядку слів, а сигнатура фіксованого
+//   розміру дозволяє порівнювати довгі тексти.
+// Для двох документів додатково виводиться різниця на рівні слів.
+
+const (
+	shingleSize   = 5
+	minHashSize   = 128  // кількість хеш-функцій у сигнатурі
+	diffContext   = 4    // слів контексту навколо зміни
+	diffMaxEdits  = 2000 // пам'ять пошуку росте як квадрат кількості правок
+	colorRed      = "\033[31m"
+	colorGreen    = "\033[32m"
+	colorReset    = "\033[0m"
+	colorCyan     = "\033[36m"
+	shingleJoiner = "\x00"
+)
+
+// Документ для порівняння: слова в порядку появи
+type document struct {
+	Name  string
+	Words []token
+}
+
+func readDocument(in input) (document, error) {
+	doc := document{Name: in.Name}
+	r, err := in.Open()
+	if err != nil {
+		return doc, err
+	}
+	defer r.Close()
+
+	reader := bufio.NewReader(r)
+	lineNo := 0
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			lineNo++
+			for _, t := range tokenizeLine(line, lineNo, 0) {
+				if t.Kind == tokenWord {
+					doc.Words = append(doc.Words, t)
+				}
+			}
+		}
+		if err == io.EOF {
+			return doc, nil
+		}
+		if err != nil {
+			return doc, err
+		}
+	}
+}
+
+func (d document) wordSet() map[string]bool {
+	set := make(map[string]bool)
+	for _, t := range d.Words {
+		set[t.Text] = true
+	}
+	return set
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	common := 0
+	for w := range a {
+		if b[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// TF-IDF вектори всіх документів
+func tfidfVectors(docs []document) []map[string]float64 {
+	df := make(map[string]int)
+	tfs := make([]map[string]int, len(docs))
+	for i, d := range docs {
+		tfs[i] = make(map[string]int)
+		for _, t := range d.Words {
+			tfs[i][t.Text]++
+		}
+		for w := range tfs[i] {
+			df[w]++
+		}
+	}
+
+	n := float64(len(docs))
+	vectors := make([]map[string]float64, len(docs))
+	for i, tf := range tfs {
+		vectors[i] = make(map[string]float64, len(tf))
+		for w, count := range tf {
+			idf := math.Log((1+n)/(1+float64(df[w]))) + 1
+			vectors[i][w] = float64(count) * idf
+		}
+	}
+	return vectors
+}
+
+func cosine(a, b map[string]float64) float64 {
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for w, x := range a {
+		dot += x * b[w]
+		normA += x * x
+	}
+	for _, y := range b {
+		normB += y * y
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+// Коефіцієнти хеш-функцій h(x) = a*x + b, однакові для всіх документів
+var minHashCoeffs = func() [minHashSize][2]uint64 {
+	var coeffs [minHashSize][2]uint64
+	state := uint64(0x9E3779B97F4A7C15)
+	next := func() uint64 { // splitmix64
+		state += 0x9E3779B97F4A7C15
+		z := state
+		z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+		z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+		return z ^ (z >> 31)
+	}
+	for i := range coeffs {
+		coeffs[i] = [2]uint64{next() | 1, next()}
+	}
+	return coeffs
+}()
+
+// MinHash-сигнатура множини шинглів документа
+func minHashSignature(d document) [minHashSize]uint64 {
+	var sig [minHashSize]uint64
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+
+	words := make([]string, len(d.Words))
+	for i, t := range d.Words {
+		words[i] = t.Text
+	}
+	size := min(shingleSize, len(words))
+	for i := 0; i+size <= len(words) && size > 0; i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+size], shingleJoiner)))
+		x := h.Sum64()
+		for j, c := range minHashCoeffs {
+			if v := c[0]*x + c[1]; v < sig[j] {
+				sig[j] = v
+			}
+		}
+	}
+	return sig
+}
+
+func minHashSimilarity(a, b [minHashSize]uint64) float64 {
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / minHashSize
+}
+
+// Порівнює всі пари документів і, якщо їх два, показує різницю
+func compareDocuments(w io.Writer, docs []document, color bool) {
+	sets := make([]map[string]bool, len(docs))
+	sigs := make([][minHashSize]uint64, len(docs))
+	for i, d := range docs {
+		sets[i] = d.wordSet()
+		sigs[i] = minHashSignature(d)
+	}
+	vectors := tfidfVectors(docs)
+
+	fmt.Fprintln(w, "=== Порівняння документів ===")
+	for i := 0; i < len(docs); i++ {
+		for j := i + 1; j < len(docs); j++ {
+			fmt.Fprintf(w, "\n%s ↔ %s\n", docs[i].Name, docs[j].Name)
+			fmt.Fprintf(w, "  Jaccard (слова):        %.3f\n", jaccard(sets[i], sets[j]))
+			fmt.Fprintf(w, "  Косинус TF-IDF:         %.3f\n", cosine(vectors[i], vectors[j]))
+			fmt.Fprintf(w, "  MinHash (%d-шингли):     %.3f\n", shingleSize, minHashSimilarity(sigs[i], sigs[j]))
+		}
+	}
+
+	if len(docs) == 2 {
+		writeWordDiff(w, docs[0], docs[1], color)
+	}
+}
+
+// ---------- Різниця на рівні слів ----------
+
+type diffKind int
+
+const (
+	diffEqual diffKind = iota
+	diffDelete
+	diffInsert
+)
+
+// Операція різниці: слова a[AFrom:ATo] або b[BFrom:BTo]
+type diffOp struct {
+	Kind       diffKind
+	AFrom, ATo int
+	BFrom, BTo int
+}
+
+// Найкоротша послідовність правок (алгоритм Маєрса).
+// Повертає false, якщо правок більше за maxEdits.
+func wordDiff(a, b []string, maxEdits int) ([]diffOp, bool) {
+	// Спільні початок і кінець не потребують пошуку
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	n, m := len(midA), len(midB)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	// trace[d] — значення v[-d..d] перед кроком d, для відновлення шляху
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= n+m && d <= maxEdits; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && midA[x] == midB[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+		if found {
+			break
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	// Відновлюємо шлях з кінця
+	var ops []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0 && (x > 0 || y > 0); d-- {
+		if d == 0 {
+			// Лишилася лише спільна діагональ від початку
+			for x > 0 {
+				x--
+				y--
+				ops = append(ops, diffOp{Kind: diffEqual, AFrom: x, ATo: x + 1, BFrom: y, BTo: y + 1})
+			}
+			break
+		}
+		vd := trace[d]
+		at := func(k int) int { return vd[k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{Kind: diffEqual, AFrom: x, ATo: x + 1, BFrom: y, BTo: y + 1})
+		}
+		if x == prevX {
+			ops = append(ops, diffOp{Kind: diffInsert, AFrom: x, ATo: x, BFrom: prevY, BTo: y})
+		} else {
+			ops = append(ops, diffOp{Kind: diffDelete, AFrom: prevX, ATo: x, BFrom: y, BTo: y})
+		}
+		x, y = prevX, prevY
+	}
+
+	// Розвертаємо, зсуваємо на спільний початок і склеюємо сусідні операції
+	var result []diffOp
+	if prefix > 0 {
+		result = append(result, diffOp{Kind: diffEqual, ATo: prefix, BTo: prefix})
+	}
+	for i := len(ops) - 1; i >= 0; i-- {
+		op := ops[i]
+		op.AFrom += prefix
+		op.ATo += prefix
+		op.BFrom += prefix
+		op.BTo += prefix
+		if last := len(result) - 1; last >= 0 && result[last].Kind == op.Kind &&
+			result[last].ATo == op.AFrom && result[last].BTo == op.BFrom {
+			result[last].ATo, result[last].BTo = op.ATo, op.BTo
+			continue
+		}
+		result = append(result, op)
+	}
+	if suffix > 0 {
+		result = append(result, diffOp{Kind: diffEqual, AFrom: len(a) - suffix, ATo: len(a), BFrom: len(b) - suffix, BTo: len(b)})
+	}
+	return result, true
+}
+
+// Виводить різницю: лише змінені місця з кількома словами контексту.
+// Видалене позначається [-так-], додане — {+так+} (у терміналі — кольором).
+func writeWordDiff(w io.Writer, a, b document, color bool) {
+	fmt.Fprintf(w, "\n--- Різниця: %s → %s ---\n", a.Name, b.Name)
+
+	textA := make([]string, len(a.Words))
+	for i, t := range a.Words {
+		textA[i] = t.Text
+	}
+	textB := make([]string, len(b.Words))
+	for i, t := range b.Words {
+		textB[i] = t.Text
+	}
+
+	ops, ok := wordDiff(textA, textB, diffMaxEdits)
+	if !ok {
+		fmt.Fprintf(w, "Тексти відрізняються більш ніж на %d правок — детальна різниця не виводиться.\n", diffMaxEdits)
+		return
+	}
+
+	// Зміна — послідовність правок підряд ("[-теплих-] {+далеких+}" — одна зміна)
+	changes := 0
+	for i, op := range ops {
+		if op.Kind != diffEqual && (i == 0 || ops[i-1].Kind == diffEqual) {
+			changes++
+		}
+	}
+	if changes == 0 {
+		fmt.Fprintln(w, "Тексти однакові (з точністю до регістру та розділових знаків).")
+		return
+	}
+
+	raw := func(words []token, from, to int) string {
+		parts := make([]string, 0, to-from)
+		for _, t := range words[from:to] {
+			parts = append(parts, t.Raw)
+		}
+		return strings.Join(parts, " ")
+	}
+	mark := func(text, open, close, col string) string {
+		if color {
+			return col + text + colorReset
+		}
+		return open + text + close
+	}
+
+	// Зміни, між якими менше 2*diffContext спільних слів, виводяться разом
+	for i := 0; i < len(ops); {
+		if ops[i].Kind == diffEqual {
+			i++
+			continue
+		}
+		// Початок фрагмента: контекст з попередньої спільної частини
+		var sb strings.Builder
+		line := 0
+		if i > 0 {
+			prev := ops[i-1]
+			from := max(prev.AFrom, prev.ATo-diffContext)
+			sb.WriteString(raw(a.Words, from, prev.ATo))
+			line = a.Words[from].Line
+		}
+
+		for ; i < len(ops); i++ {
+			op := ops[i]
+			switch op.Kind {
+			case diffDelete:
+				if line == 0 {
+					line = a.Words[op.AFrom].Line
+				}
+				sb.WriteString(" " + mark(raw(a.Words, op.AFrom, op.ATo), "[-", "-]", colorRed))
+				continue
+			case diffInsert:
+				sb.WriteString(" " + mark(raw(b.Words, op.BFrom, op.BTo), "{+", "+}", colorGreen))
+				continue
+			}
+			// Спільна частина: коротка — всередині фрагмента, довга — завершує його
+			if op.ATo-op.AFrom <= 2*diffContext && i+1 < len(ops) {
+				sb.WriteString(" " + raw(a.Words, op.AFrom, op.ATo))
+				continue
+			}
+			sb.WriteString(" " + raw(a.Words, op.AFrom, min(op.ATo, op.AFrom+diffContext)))
+			break
+		}
+
+		header := "@@ рядок " + fmt.Sprint(max(line, 1)) + " @@"
+		if color {
+			header = colorCyan + header + colorReset
+		}
+		fmt.Fprintln(w, header)
+		fmt.Fprintln(w, strings.TrimSpace(sb.String()))
+	}
+	fmt.Fprintf(w, "Змін: %d\n", changes)
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golanghomeworks/validate"
+)
+
+// ---------- Виділення сутностей ----------
+// Кандидати знаходяться регулярними виразами, а потім перевіряються тими
+// самими правилами, що й у HW2 (спільний пакет validate), тож у звіт
+// потрапляють лише валідні значення. Типи перевіряються по черзі (URL,
+// email, IP, дата, сума, телефон): фрагмент, уже зайнятий попереднім типом,
+// іншим не розглядається — IP чи email усередині URL окремо не виводяться.
+//
+// Кандидат не повинен бути частиною довшого слова чи числа: поруч не може
+// стояти літера або цифра, а крапка чи кома між цифрами продовжує число
+// (1.2.3.4.5 — не IP, 1,500 грн — не сума).
+
+const entitySpace = `[  \x{202F}]` // пробіл, нерозривний і вузький нерозривний
+
+var entityAmount = `\d{1,3}(?:` + entitySpace + `\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?`
+
+// Тип сутності
+type entityKind struct {
+	Name  string // назва для -entity-types
+	Title string
+	Mask  string // чим замінюється у режимі -redact
+	Re    *regexp.Regexp
+	// Перевіряє кандидата; повертає нормалізоване значення та довжину
+	// валідної частини (телефон може виявитися коротшим за кандидата)
+	Check func(raw string) (value string, n int, ok bool)
+}
+
+var entityKinds = []entityKind{
+	{"url", "URL-адреси", "[URL]", regexp.MustCompile(`(?i)https?://[^\s<>"«»]+`), checkURLEntity},
+	{"email", "Email-адреси", "[EMAIL]", regexp.MustCompile(`[A-Za-z0-9._-]+@[A-Za-z0-9.-]+`), checkEmailEntity},
+	{"ip", "IP-адреси", "[IP]", regexp.MustCompile(`\d{1,3}(?:\.\d{1,3}){3}`), checkIPEntity},
+	{"date", "Дати", "[DATE]", regexp.MustCompile(`\d{1,2}\.\d{1,2}\.\d{4}|\d{4}-\d{2}-\d{2}|\d{2}[/-]\d{2}[/-]\d{4}`), checkDateEntity},
+	{"money", "Грошові суми", "[MONEY]", regexp.MustCompile(`(?i)[$€£₴]` + entitySpace + `?(?:` + entityAmount + `)|(?:` + entityAmount + `)` + entitySpace +
+		`?(?:грн|гривень|гривн[яіюь]|uah|usd|eur|gbp|дол(?:арів|ари|ара|ар)|євро|[₴$€£])`), checkMoneyEntity},
+	{"phone", "Телефонні номери", "[PHONE]", regexp.MustCompile(`\+?\(?\d[\d ()-]{7,}\d`), checkPhoneEntity},
+}
+
+// Знайдена сутність
+type entity struct {
+	Kind  string
+	Raw   string // як у тексті
+	Value string // нормалізований вигляд
+	Line  int
+	Col   int // номер символу в рядку (з 1)
+	Start int // зсув у байтах від початку рядка
+	End   int
+}
+
+// Типи сутностей за списком назв через кому; порожній список — усі
+func parseEntityKinds(list string) ([]entityKind, error) {
+	if strings.TrimSpace(list) == "" {
+		return entityKinds, nil
+	}
+	want := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if entityKindIndex(name) == -1 {
+			names := make([]string, len(entityKinds))
+			for i, k := range entityKinds {
+				names[i] = k.Name
+			}
+			return nil, fmt.Errorf("невідомий тип сутності: %s (%s)", name, strings.Join(names, ", "))
+		}
+		want[name] = true
+	}
+	var kinds []entityKind
+	for _, k := range entityKinds {
+		if want[k.Name] {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds, nil
+}
+
+func entityKindIndex(name string) int {
+	for i, k := range entityKinds {
+		if k.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Знаходить валідні сутності в рядку (у порядку появи)
+func findEntities(line string, lineNo int) []entity {
+	var found []entity
+	// Перша знайдена сутність, що перетинає line[start:end]
+	claimed := func(start, end int) *entity {
+		var first *entity
+		for i, e := range found {
+			if start < e.End && e.Start < end && (first == nil || e.Start < first.Start) {
+				first = &found[i]
+			}
+		}
+		return first
+	}
+
+	for _, kind := range entityKinds {
+		for pos := 0; pos < len(line); {
+			loc := kind.Re.FindStringIndex(line[pos:])
+			if loc == nil {
+				break
+			}
+			start, end := pos+loc[0], pos+loc[1]
+			pos = end
+			// Кандидат обрізається до зайнятого фрагмента; якщо зайнятий
+			// сам початок, пошук продовжується після фрагмента
+			if e := claimed(start, end); e != nil {
+				if e.Start <= start {
+					pos = e.End
+					continue
+				}
+				end = e.Start
+			}
+			value, n, ok := kind.Check(line[start:end])
+			if !ok {
+				continue
+			}
+			end = start + n
+			if joinedToText(line, start, end) {
+				continue
+			}
+			found = append(found, entity{
+				Kind:  kind.Name,
+				Raw:   line[start:end],
+				Value: value,
+				Line:  lineNo,
+				Col:   utf8.RuneCountInString(line[:start]) + 1,
+				Start: start,
+				End:   end,
+			})
+		}
+	}
+
+	// Упорядковуємо за позицією (вставкою — сутностей у рядку небагато)
+	for i := 1; i < len(found); i++ {
+		for j := i; j > 0 && found[j].Start < found[j-1].Start; j-- {
+			found[j], found[j-1] = found[j-1], found[j]
+		}
+	}
+	return found
+}
+
+// Чи продовжує фрагмент line[start:end] сусіднє слово або число
+func joinedToText(line string, start, end int) bool {
+	if start > 0 {
+		prev, size := utf8.DecodeLastRuneInString(line[:start])
+		if unicode.IsLetter(prev) || unicode.IsDigit(prev) {
+			return true
+		}
+		if prev == '.' || prev == ',' {
+			before, _ := utf8.DecodeLastRuneInString(line[:start-size])
+			if unicode.IsDigit(before) {
+				return true
+			}
+		}
+	}
+	if end < len(line) {
+		next, size := utf8.DecodeRuneInString(line[end:])
+		if unicode.IsLetter(next) || unicode.IsDigit(next) {
+			return true
+		}
+		if next == '.' || next == ',' {
+			after, _ := utf8.DecodeRuneInString(line[end+size:])
+			if unicode.IsDigit(after) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ---------- Перевірка кандидатів ----------
+
+func checkURLEntity(raw string) (string, int, bool) {
+	// Розділовий знак у кінці належить реченню, а не адресі;
+	// дужка — лише якщо в адресі немає відкривної
+	for raw != "" {
+		last, size := utf8.DecodeLastRuneInString(raw)
+		if strings.ContainsRune(".,;:!?'\"»", last) ||
+			(last == ')' && strings.Count(raw, "(") < strings.Count(raw, ")")) {
+			raw = raw[:len(raw)-size]
+			continue
+		}
+		break
+	}
+	value := validate.NormalizeURL(raw)
+	ok, _ := validate.URL(value)
+	return value, len(raw), ok
+}
+
+func checkEmailEntity(raw string) (string, int, bool) {
+	raw = strings.TrimRight(raw, ".-")
+	value := validate.NormalizeEmail(raw)
+	ok, _ := validate.Email(value)
+	return value, len(raw), ok
+}
+
+func checkIPEntity(raw string) (string, int, bool) {
+	ok, _ := validate.IP(raw)
+	return raw, len(raw), ok
+}
+
+func checkDateEntity(raw string) (string, int, bool) {
+	t, err := validate.ParseDate(raw)
+	if err != nil {
+		return "", 0, false
+	}
+	return t.Format("2006-01-02"), len(raw), true
+}
+
+// Номер перевіряється цілим, а якщо не підходить — без останніх груп
+// цифр: "050 123 45 67 2024" містить номер "050 123 45 67"
+func checkPhoneEntity(raw string) (string, int, bool) {
+	raw = strings.TrimRight(raw, " -(")
+	for {
+		value := validate.NormalizePhone(raw)
+		if ok, _ := validate.Phone(value); ok {
+			return value, len(raw), true
+		}
+		cut := strings.LastIndexAny(raw, " -")
+		if cut == -1 {
+			return "", 0, false
+		}
+		raw = strings.TrimRight(raw[:cut], " -(")
+		if digitCount(raw) < 10 {
+			return "", 0, false
+		}
+	}
+}
+
+func digitCount(s string) int {
+	n := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// Сума: число з групами по три цифри або без них, копійки через кому
+// чи крапку, та валюта до або після числа. Нормалізований вигляд —
+// "1500.00 UAH".
+func checkMoneyEntity(raw string) (string, int, bool) {
+	lower := strings.ToLower(raw)
+	currency := "UAH"
+	switch {
+	case strings.ContainsAny(lower, "$") || strings.Contains(lower, "usd") || strings.Contains(lower, "дол"):
+		currency = "USD"
+	case strings.ContainsAny(lower, "€") || strings.Contains(lower, "eur") || strings.Contains(lower, "євро"):
+		currency = "EUR"
+	case strings.ContainsAny(lower, "£") || strings.Contains(lower, "gbp"):
+		currency = "GBP"
+	}
+
+	var digits strings.Builder
+	for _, r := range raw {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case r == '.' || r == ',':
+			digits.WriteByte('.')
+		}
+	}
+	number := digits.String()
+	// Ведучий нуль можливий лише перед копійками: 0,50 грн, але не 007 грн
+	if len(number) > 1 && number[0] == '0' && number[1] != '.' {
+		return "", 0, false
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency), len(raw), true
+}
+
+// ---------- Обробка тексту ----------
+
+// Замінює сутності рядка масками
+func redactLine(line string, found []entity) string {
+	var sb strings.Builder
+	pos := 0
+	for _, e := range found {
+		sb.WriteString(line[pos:e.Start])
+		sb.WriteString(entityKinds[entityKindIndex(e.Kind)].Mask)
+		pos = e.End
+	}
+	sb.WriteString(line[pos:])
+	return sb.String()
+}
+
+// Шукає сутності в тексті порядково; якщо redacted не nil, записує туди
+// текст із замаскованими сутностями. Шукаються всі типи, а залишаються
+// лише вибрані: інакше дата, не вибрана у -entity-types, могла б стати
+// частиною кандидата в телефони.
+func scanEntities(r io.Reader, kinds []entityKind, redacted io.Writer) ([]entity, error) {
+	wanted := make(map[string]bool)
+	for _, k := range kinds {
+		wanted[k.Name] = true
+	}
+
+	reader := bufio.NewReader(r)
+	var out *bufio.Writer
+	if redacted != nil {
+		out = bufio.NewWriter(redacted)
+		defer out.Flush()
+	}
+
+	var all []entity
+	lineNo := 0
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			lineNo++
+			var found []entity
+			for _, e := range findEntities(line, lineNo) {
+				if wanted[e.Kind] {
+					found = append(found, e)
+				}
+			}
+			all = append(all, found...)
+			if out != nil {
+				out.WriteString(redactLine(line, found))
+			}
+		}
+		if err == io.EOF {
+			return all, nil
+		}
+		if err != nil {
+			return all, err
+		}
+	}
+}
+
+// Виводить сутності, згруповані за типом, з позиціями рядок:колонка
+func writeEntities(w io.Writer, name string, found []entity, kinds []entityKind) {
+	fmt.Fprintf(w, "\n--- Сутності: %s ---\n", name)
+	if len(found) == 0 {
+		fmt.Fprintln(w, "Сутностей не знайдено.")
+		return
+	}
+	for _, kind := range kinds {
+		var list []entity
+		for _, e := range found {
+			if e.Kind == kind.Name {
+				list = append(list, e)
+			}
+		}
+		if len(list) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s: %d\n", kind.Title, len(list))
+		for _, e := range list {
+			pos := fmt.Sprintf("%d:%d", e.Line, e.Col)
+			if e.Value != e.Raw {
+				fmt.Fprintf(w, "  %-9s %s → %s\n", pos, e.Raw, e.Value)
+			} else {
+				fmt.Fprintf(w, "  %-9s %s\n", pos, e.Raw)
+			}
+		}
+	}
+}
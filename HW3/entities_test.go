@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golanghomeworks/validate"
+)
+
+func init() {
+	if err := validate.LoadDomainLists(); err != nil {
+		panic(err)
+	}
+}
+
+func TestFindEntities(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string // "тип:значення" у порядку появи
+	}{
+		{"Пишіть на Ivan@Example.COM або bad@site.invalid.",
+			[]string{"email:Ivan@example.com"}},
+		{"Сайт https://Example.com:443/a?q=1), а не ftp://x.com",
+			[]string{"url:https://example.com/a?q=1"}},
+		// Публічний суфікс — не адреса сайту, як і в HW2
+		{"user@co.ua, https://com.ua, https://shop.com.ua/ і me@mail.gov.ua",
+			[]string{"url:https://shop.com.ua/", "email:me@mail.gov.ua"}},
+		{"Дзвоніть (050) 123-45-67 або +380 12 345 67 89",
+			[]string{"phone:+380501234567"}},
+		{"Сервер 192.168.1.10, не 999.1.1.1 і не 1.2.3.4.5",
+			[]string{"ip:192.168.1.10"}},
+		{"29.02.2024 так, 31.04.2024 ні, 2024-03-15 так",
+			[]string{"date:2024-02-29", "date:2024-03-15"}},
+		{"Ціна 1 500 грн, знижка $20, 12,50 євро; 1,500 грн і 007 грн — ні",
+			[]string{"money:1500.00 UAH", "money:20.00 USD", "money:12.50 EUR"}},
+		// IP усередині URL та дата перед номером не заважають
+		{"http://10.0.0.1.example.com/ 2024-01-02 050 123 45 67 2025",
+			[]string{"url:http://10.0.0.1.example.com/", "date:2024-01-02", "phone:+380501234567"}},
+	}
+	for _, tc := range tests {
+		var got []string
+		for _, e := range findEntities(tc.line, 1) {
+			got = append(got, e.Kind+":"+e.Value)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("findEntities(%q) = %q, очікувалось %q", tc.line, got, tc.want)
+		}
+	}
+}
+
+func TestRedactEntities(t *testing.T) {
+	kinds, err := parseEntityKinds("email,phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	found, err := scanEntities(strings.NewReader("Я — ivan@example.com,\nтел. 0501234567, 1 500 грн\n"), kinds, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Я — [EMAIL],\nтел. [PHONE], 1 500 грн\n"
+	if out.String() != want {
+		t.Errorf("маскування: %q, очікувалось %q", out.String(), want)
+	}
+	if len(found) != 2 || found[1].Line != 2 || found[1].Col != 6 {
+		t.Errorf("позиції: %+v", found)
+	}
+}
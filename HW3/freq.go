@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ---------- Частотний словник ----------
+
+//go:embed data/stopwords_uk.txt
+var bundledStopwordsUK string
+
+//go:embed data/stopwords_en.txt
+var bundledStopwordsEN string
+
+// Параметри частотного звіту
+type freqOptions struct {
+	Enabled   bool
+	Top       int             // скільки слів показати (0 — усі)
+	MinLen    int             // мінімальна довжина слова у символах
+	MinCount  int             // мінімальна кількість входжень
+	Stopwords map[string]bool // слова, які не враховуються
+	Format    string          // table, csv або json
+	Ngrams    []int           // розміри n-грам для звіту (порожньо — без n-грам)
+	NgramSort string          // count, pmi або llr
+	// Додавати вбудовані стоп-слова мови, визначеної для кожного тексту
+	AutoStopwords bool
+}
+
+// Рядок частотного звіту
+type wordFreq struct {
+	Word    string  `json:"word"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// Частотний звіт для одного джерела
+type freqReport struct {
+	Name  string     `json:"name"`
+	Total int        `json:"total_words"`
+	Words []wordFreq `json:"words"`
+}
+
+// Розбирає список слів з рядка: одне слово на рядок, '#' — коментар.
+// Слова нормалізуються так само, як токени тексту (регістр, апостроф)
+func parseWordList(data string, into map[string]bool) {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		into[normalizeWord(line)] = true
+	}
+}
+
+// Збирає стоп-слова з вбудованих списків (uk, en) та файлів користувача
+func loadStopwords(langs string, files []string) (map[string]bool, error) {
+	stopwords := make(map[string]bool)
+
+	for _, lang := range strings.Split(langs, ",") {
+		switch strings.TrimSpace(lang) {
+		case "":
+		case "uk":
+			parseWordList(bundledStopwordsUK, stopwords)
+		case "en":
+			parseWordList(bundledStopwordsEN, stopwords)
+		default:
+			return nil, fmt.Errorf("немає вбудованого списку стоп-слів для мови %q", lang)
+		}
+	}
+
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		var sb strings.Builder
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			sb.WriteString(scanner.Text())
+			sb.WriteString("\n")
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		parseWordList(sb.String(), stopwords)
+	}
+	return stopwords, nil
+}
+
+// Параметри звіту для конкретного тексту: з AutoStopwords до стоп-слів
+// додається вбудований список визначеної мови (якщо він є)
+func (o freqOptions) forText(stats textStats) freqOptions {
+	if !o.AutoStopwords {
+		return o
+	}
+	var bundled string
+	switch detectLanguage(stats.Trigrams).Lang {
+	case "uk":
+		bundled = bundledStopwordsUK
+	case "en":
+		bundled = bundledStopwordsEN
+	default:
+		return o
+	}
+
+	stopwords := make(map[string]bool, len(o.Stopwords))
+	for w := range o.Stopwords {
+		stopwords[w] = true
+	}
+	parseWordList(bundled, stopwords)
+	o.Stopwords = stopwords
+	return o
+}
+
+// Будує звіт з накопичених частот з урахуванням фільтрів
+func buildFreqReport(stats textStats, opts freqOptions) freqReport {
+	report := freqReport{Name: stats.Name, Total: stats.Words, Words: []wordFreq{}}
+
+	for word, count := range stats.Freq {
+		if opts.Stopwords[word] || utf8.RuneCountInString(word) < opts.MinLen || count < opts.MinCount {
+			continue
+		}
+		percent := 0.0
+		if stats.Words > 0 {
+			percent = float64(count) * 100 / float64(stats.Words)
+		}
+		report.Words = append(report.Words, wordFreq{Word: word, Count: count, Percent: percent})
+	}
+
+	// За спаданням кількості, при рівності — за алфавітом
+	sort.Slice(report.Words, func(i, j int) bool {
+		if report.Words[i].Count != report.Words[j].Count {
+			return report.Words[i].Count > report.Words[j].Count
+		}
+		return report.Words[i].Word < report.Words[j].Word
+	})
+
+	if opts.Top > 0 && len(report.Words) > opts.Top {
+		report.Words = report.Words[:opts.Top]
+	}
+	return report
+}
+
+// Виводить звіти у вибраному форматі
+func writeFreqReports(w io.Writer, reports []freqReport, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(reports)
+
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"source", "word", "count", "percent"})
+		for _, r := range reports {
+			for _, f := range r.Words {
+				writer.Write([]string{r.Name, f.Word, strconv.Itoa(f.Count), strconv.FormatFloat(f.Percent, 'f', 2, 64)})
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "table", "":
+		for _, r := range reports {
+			writeFreqTable(w, r)
+		}
+		return nil
+	}
+	return fmt.Errorf("невідомий формат: %s (table, csv, json)", format)
+}
+
+func writeFreqTable(w io.Writer, r freqReport) {
+	fmt.Fprintf(w, "\n--- Частотний словник: %s (усього слів: %d) ---\n", r.Name, r.Total)
+	if len(r.Words) == 0 {
+		fmt.Fprintln(w, "Немає слів, що відповідають фільтрам.")
+		return
+	}
+
+	width := utf8.RuneCountInString("Слово")
+	for _, f := range r.Words {
+		width = max(width, utf8.RuneCountInString(f.Word))
+	}
+	fmt.Fprintf(w, "%4s  %s  %s  %7s\n", "#", padRight("Слово", width), padLeft("Кількість", 9), "%")
+	for i, f := range r.Words {
+		fmt.Fprintf(w, "%4d  %s  %9d  %6.2f%%\n", i+1, padRight(f.Word, width), f.Count, f.Percent)
+	}
+}
+
+// Доповнює рядок пробілами до ширини у символах (fmt рахує байти)
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+func padLeft(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return strings.Repeat(" ", width-n) + s
+	}
+	return s
+}
@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestParseWordListNormalizes(t *testing.T) {
+	stop := make(map[string]bool)
+	parseWordList("# коментар\nОбов’язково\nпів‑року\n", stop)
+	for _, w := range []string{"обов'язково", "пів-року"} {
+		if !stop[w] {
+			t.Errorf("стоп-слово %q не знайдено: %v", w, stop)
+		}
+	}
+	if len(stop) != 2 {
+		t.Errorf("зайві записи: %v", stop)
+	}
+}
@@ -0,0 +1,697 @@
+package main
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ---------- Інвертований індекс ----------
+// Індекс каталогу зберігається у файлі (gob) і містить для кожного слова
+// список документів з позиціями слова (номер слова в документі).
+// Оновлення інкрементне: файл переіндексовується, лише якщо змінилися його
+// розмір або час зміни; видалені файли прибираються з індексу.
+//
+// Запити:
+//   кіт пес          — обидва слова (AND за замовчуванням)
+//   кіт OR пес       — будь-яке зі слів
+//   кіт NOT пес      — кіт, але не пес
+//   "кіт спав"       — фраза: слова підряд
+//   кот*             — слова з префіксом
+//   (кіт OR пес) AND "на печі"
+// Результати впорядковуються за BM25.
+
+const (
+	indexVersion = 1
+	indexName    = ".hw3index"
+	bm25K1       = 1.2
+	bm25B        = 0.75
+	snippetWords = 8 // слів контексту з кожного боку у фрагменті
+)
+
+// Документ в індексі
+type indexedDoc struct {
+	Path    string // відносно кореня індексу
+	ModTime int64
+	Size    int64
+	Length  int      // кількість слів
+	Terms   []string // різні слова документа — щоб швидко видалити його з індексу
+}
+
+// Входження слова в документ
+type posting struct {
+	Doc       int
+	Positions []int
+}
+
+type invertedIndex struct {
+	Version  int
+	Root     string
+	NextID   int
+	Docs     map[int]*indexedDoc
+	Postings map[string][]posting // списки відсортовані за Doc
+}
+
+func newIndex(root string) *invertedIndex {
+	return &invertedIndex{
+		Version:  indexVersion,
+		Root:     root,
+		Docs:     make(map[int]*indexedDoc),
+		Postings: make(map[string][]posting),
+	}
+}
+
+// Завантажує індекс з файлу; якщо файлу немає — повертає порожній
+func loadIndex(path, root string) (*invertedIndex, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return newIndex(root), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	idx := &invertedIndex{}
+	if err := gob.NewDecoder(bufio.NewReader(file)).Decode(idx); err != nil {
+		return nil, fmt.Errorf("пошкоджений файл індексу %s: %w", path, err)
+	}
+	if idx.Version != indexVersion {
+		return nil, fmt.Errorf("індекс %s створено іншою версією програми, видаліть його", path)
+	}
+	if root != "" {
+		idx.Root = root
+	}
+	return idx, nil
+}
+
+// Зберігає індекс атомарно: спершу у тимчасовий файл, потім перейменовує
+func (idx *invertedIndex) save(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".hw3index-*")
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	if err := gob.NewEncoder(w).Encode(idx); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Результат оновлення індексу
+type indexUpdate struct {
+	Added, Updated, Removed, Unchanged int
+}
+
+// Синхронізує індекс із вмістом каталогу. exts — розширення файлів (".txt").
+func (idx *invertedIndex) update(exts []string, skip string) (indexUpdate, error) {
+	var result indexUpdate
+	byPath := make(map[string]int, len(idx.Docs))
+	for id, doc := range idx.Docs {
+		byPath[doc.Path] = id
+	}
+	seen := make(map[string]bool)
+
+	err := filepath.WalkDir(idx.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != idx.Root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !hasExt(path, exts) || sameFile(path, skip) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(idx.Root, path)
+		if err != nil {
+			return err
+		}
+		seen[rel] = true
+
+		id, known := byPath[rel]
+		if known {
+			doc := idx.Docs[id]
+			if doc.ModTime == info.ModTime().UnixNano() && doc.Size == info.Size() {
+				result.Unchanged++
+				return nil
+			}
+			idx.remove(id)
+			result.Updated++
+		} else {
+			result.Added++
+		}
+		return idx.add(rel, info)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for path, id := range byPath {
+		if !seen[path] {
+			idx.remove(id)
+			result.Removed++
+		}
+	}
+	return result, nil
+}
+
+func hasExt(path string, exts []string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+func sameFile(a, b string) bool {
+	if b == "" {
+		return false
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// Індексує файл
+func (idx *invertedIndex) add(rel string, info fs.FileInfo) error {
+	file, err := os.Open(filepath.Join(idx.Root, rel))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	positions := make(map[string][]int)
+	pos := 0
+	err = eachWord(file, func(t token) bool {
+		positions[t.Text] = append(positions[t.Text], pos)
+		pos++
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	id := idx.NextID
+	idx.NextID++
+	doc := &indexedDoc{Path: rel, ModTime: info.ModTime().UnixNano(), Size: info.Size(), Length: pos}
+	for term, list := range positions {
+		idx.Postings[term] = append(idx.Postings[term], posting{Doc: id, Positions: list})
+		doc.Terms = append(doc.Terms, term)
+	}
+	sort.Strings(doc.Terms)
+	idx.Docs[id] = doc
+	return nil
+}
+
+// Видаляє документ з усіх списків
+func (idx *invertedIndex) remove(id int) {
+	for _, term := range idx.Docs[id].Terms {
+		list := idx.Postings[term]
+		i := sort.Search(len(list), func(i int) bool { return list[i].Doc >= id })
+		if i < len(list) && list[i].Doc == id {
+			list = append(list[:i], list[i+1:]...)
+		}
+		if len(list) == 0 {
+			delete(idx.Postings, term)
+		} else {
+			idx.Postings[term] = list
+		}
+	}
+	delete(idx.Docs, id)
+}
+
+// Викликає fn для кожного слова тексту по порядку; false зупиняє читання
+func eachWord(r io.Reader, fn func(token) bool) error {
+	reader := bufio.NewReader(r)
+	lineNo := 0
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			lineNo++
+			for _, t := range tokenizeLine(line, lineNo, 0) {
+				if t.Kind == tokenWord && !fn(t) {
+					return nil
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ---------- Запити ----------
+
+type docSet map[int]bool
+
+type queryNode interface {
+	eval(q *queryEval) docSet
+}
+
+type (
+	termNode   struct{ term string }
+	prefixNode struct{ prefix string }
+	phraseNode struct{ words []string }
+	andNode    struct{ left, right queryNode }
+	orNode     struct{ left, right queryNode }
+	notNode    struct{ node queryNode }
+)
+
+// Стан обчислення запиту: слова, що дали збіги (для BM25 і підсвічування)
+type queryEval struct {
+	idx   *invertedIndex
+	terms map[string]bool
+	neg   int // глибина вкладеності NOT: слова під NOT не враховуються
+}
+
+func (q *queryEval) docsWith(term string) docSet {
+	set := make(docSet)
+	for _, p := range q.idx.Postings[term] {
+		set[p.Doc] = true
+	}
+	if len(set) > 0 && q.neg%2 == 0 {
+		q.terms[term] = true
+	}
+	return set
+}
+
+func (n termNode) eval(q *queryEval) docSet { return q.docsWith(n.term) }
+
+func (n prefixNode) eval(q *queryEval) docSet {
+	set := make(docSet)
+	for term := range q.idx.Postings {
+		if strings.HasPrefix(term, n.prefix) {
+			for doc := range q.docsWith(term) {
+				set[doc] = true
+			}
+		}
+	}
+	return set
+}
+
+func (n phraseNode) eval(q *queryEval) docSet {
+	set := make(docSet)
+	if len(n.words) == 0 {
+		return set
+	}
+	// Позиції кожного слова фрази в кожному документі
+	lists := make([]map[int][]int, len(n.words))
+	for i, w := range n.words {
+		lists[i] = make(map[int][]int)
+		for _, p := range q.idx.Postings[w] {
+			lists[i][p.Doc] = p.Positions
+		}
+	}
+	for doc, first := range lists[0] {
+		for _, start := range first {
+			if phraseAt(lists, doc, start) {
+				set[doc] = true
+				break
+			}
+		}
+	}
+	if len(set) > 0 && q.neg%2 == 0 {
+		for _, w := range n.words {
+			q.terms[w] = true
+		}
+	}
+	return set
+}
+
+func phraseAt(lists []map[int][]int, doc, start int) bool {
+	for i := 1; i < len(lists); i++ {
+		positions := lists[i][doc]
+		j := sort.SearchInts(positions, start+i)
+		if j == len(positions) || positions[j] != start+i {
+			return false
+		}
+	}
+	return true
+}
+
+func (n andNode) eval(q *queryEval) docSet {
+	left, right := n.left.eval(q), n.right.eval(q)
+	set := make(docSet)
+	for doc := range left {
+		if right[doc] {
+			set[doc] = true
+		}
+	}
+	return set
+}
+
+func (n orNode) eval(q *queryEval) docSet {
+	set := n.left.eval(q)
+	for doc := range n.right.eval(q) {
+		set[doc] = true
+	}
+	return set
+}
+
+func (n notNode) eval(q *queryEval) docSet {
+	q.neg++
+	excluded := n.node.eval(q)
+	q.neg--
+	set := make(docSet)
+	for doc := range q.idx.Docs {
+		if !excluded[doc] {
+			set[doc] = true
+		}
+	}
+	return set
+}
+
+// Лексема запиту
+type queryToken struct {
+	kind string // word, prefix, phrase, and, or, not, (, )
+	text string
+}
+
+func lexQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, queryToken{kind: string(r)})
+			i++
+		case r == '"' || r == '«' || r == '„':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' && runes[end] != '»' && runes[end] != '“' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("незакриті лапки у запиті")
+			}
+			tokens = append(tokens, queryToken{kind: "phrase", text: string(runes[i+1 : end])})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '(' && runes[end] != ')' && runes[end] != '"' {
+				end++
+			}
+			word := string(runes[i:end])
+			i = end
+			switch word {
+			case "AND":
+				tokens = append(tokens, queryToken{kind: "and"})
+			case "OR":
+				tokens = append(tokens, queryToken{kind: "or"})
+			case "NOT":
+				tokens = append(tokens, queryToken{kind: "not"})
+			default:
+				if strings.HasSuffix(word, "*") {
+					tokens = append(tokens, queryToken{kind: "prefix", text: normalizeWord(strings.TrimRight(word, "*"))})
+				} else {
+					tokens = append(tokens, queryToken{kind: "word", text: word})
+				}
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// Розбір запиту методом рекурсивного спуску:
+//
+//	expr    = and { OR and }
+//	and     = not { [AND] not }
+//	not     = NOT not | primary
+//	primary = "(" expr ")" | фраза | слово | префікс*
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func parseQuery(query string) (queryNode, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("порожній запит")
+	}
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("зайва лексема у запиті: %q", p.tokens[p.pos].kind)
+	}
+	return node, nil
+}
+
+func (p *queryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos].kind
+	}
+	return ""
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "or" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek() {
+		case "and":
+			p.pos++
+		case "word", "prefix", "phrase", "not", "(":
+			// AND за замовчуванням
+		default:
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *queryParser) parseNot() (queryNode, error) {
+	if p.peek() == "not" {
+		p.pos++
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("запит обривається")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	switch t.kind {
+	case "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("бракує закривної дужки")
+		}
+		p.pos++
+		return node, nil
+	case "phrase":
+		return phraseNode{words: lineWords(t.text)}, nil
+	case "prefix":
+		if t.text == "" {
+			return nil, fmt.Errorf("порожній префікс")
+		}
+		return prefixNode{t.text}, nil
+	case "word":
+		words := lineWords(t.text)
+		switch len(words) {
+		case 0:
+			return nil, fmt.Errorf("у лексемі %q немає слова", t.text)
+		case 1:
+			return termNode{words[0]}, nil
+		}
+		// "кота-воркота" або "don't" розбились би інакше — шукаємо як фразу
+		return phraseNode{words: words}, nil
+	}
+	return nil, fmt.Errorf("неочікувана лексема %q", t.kind)
+}
+
+// ---------- Ранжування та фрагменти ----------
+
+// Результат пошуку
+type searchHit struct {
+	Doc     *indexedDoc
+	Score   float64
+	Snippet string
+}
+
+// Виконує запит і повертає не більше limit документів за спаданням BM25
+// та загальну кількість знайдених документів
+func (idx *invertedIndex) search(query string, limit int) ([]searchHit, int, map[string]bool, error) {
+	node, err := parseQuery(query)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	q := &queryEval{idx: idx, terms: make(map[string]bool)}
+	docs := node.eval(q)
+
+	totalLen := 0
+	for _, doc := range idx.Docs {
+		totalLen += doc.Length
+	}
+	avgLen := float64(totalLen) / math.Max(float64(len(idx.Docs)), 1)
+	n := float64(len(idx.Docs))
+
+	scores := make(map[int]float64, len(docs))
+	for term := range q.terms {
+		list := idx.Postings[term]
+		df := float64(len(list))
+		idf := math.Log((n-df+0.5)/(df+0.5) + 1)
+		for _, p := range list {
+			if !docs[p.Doc] {
+				continue
+			}
+			tf := float64(len(p.Positions))
+			norm := 1 - bm25B + bm25B*float64(idx.Docs[p.Doc].Length)/avgLen
+			scores[p.Doc] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	hits := make([]searchHit, 0, len(docs))
+	for id := range docs {
+		hits = append(hits, searchHit{Doc: idx.Docs[id], Score: scores[id]})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Doc.Path < hits[j].Doc.Path
+	})
+	total := len(hits)
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, total, q.terms, nil
+}
+
+// Фрагмент тексту навколо першого знайденого слова
+func (idx *invertedIndex) snippet(doc *indexedDoc, terms map[string]bool, color bool) string {
+	file, err := os.Open(filepath.Join(idx.Root, doc.Path))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	var window []token // останні слова до збігу
+	var words []string
+	found := -1
+	cutBefore, cutAfter := false, false
+	eachWord(file, func(t token) bool {
+		hit := terms[t.Text]
+		if found == -1 {
+			if !hit {
+				window = append(window, t)
+				if len(window) > snippetWords {
+					window = window[1:]
+					cutBefore = true
+				}
+				return true
+			}
+			found = 0
+			for _, w := range window {
+				words = append(words, w.Raw)
+			}
+		}
+		raw := t.Raw
+		if hit {
+			if color {
+				raw = colorGreen + raw + colorReset
+			} else {
+				raw = "[" + raw + "]"
+			}
+		}
+		if found > snippetWords {
+			cutAfter = true
+			return false
+		}
+		words = append(words, raw)
+		found++
+		return true
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	snippet := strings.Join(words, " ")
+	if cutBefore {
+		snippet = "…" + snippet
+	}
+	if cutAfter {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// Виводить результати пошуку
+func writeSearchHits(w io.Writer, idx *invertedIndex, query string, hits []searchHit, total int, terms map[string]bool, color bool) {
+	fmt.Fprintf(w, "Запит: %s — знайдено документів: %d, показано %d\n", query, total, len(hits))
+	for i, hit := range hits {
+		fmt.Fprintf(w, "\n%d. %s (BM25 %.3f, слів: %d)\n", i+1, hit.Doc.Path, hit.Score, hit.Doc.Length)
+		if s := idx.snippet(hit.Doc, terms, color); s != "" {
+			fmt.Fprintf(w, "   %s\n", s)
+		}
+	}
+}
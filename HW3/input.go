@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ---------- Джерела тексту ----------
+
+// Одне джерело тексту: файл або стандартний ввід
+type input struct {
+	Name string
+	Open func() (io.ReadCloser, error)
+}
+
+const stdinName = "<stdin>"
+
+// Перевіряє, чи підключений стандартний ввід до термінала.
+// Якщо ні (конвеєр або перенаправлення), підказки не виводяться.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Перетворює аргументи командного рядка на список джерел.
+// Аргумент може бути шляхом, шаблоном (*.txt, docs/*.md) або "-" для stdin.
+func collectInputs(args []string) ([]input, error) {
+	var inputs []input
+	seen := make(map[string]bool)
+
+	for _, arg := range args {
+		if arg == "-" {
+			inputs = append(inputs, stdinInput())
+			continue
+		}
+
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("некоректний шаблон %q: %w", arg, err)
+		}
+		if len(matches) == 0 {
+			// Не шаблон і не існуючий файл — нехай помилку покаже відкриття
+			matches = []string{arg}
+		}
+		sort.Strings(matches)
+
+		for _, path := range matches {
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				continue
+			}
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			inputs = append(inputs, fileInput(path))
+		}
+	}
+	return inputs, nil
+}
+
+func fileInput(path string) input {
+	return input{
+		Name: path,
+		Open: func() (io.ReadCloser, error) { return os.Open(path) },
+	}
+}
+
+func stdinInput() input {
+	return input{
+		Name: stdinName,
+		Open: func() (io.ReadCloser, error) { return io.NopCloser(os.Stdin), nil },
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ---------- Визначення мови ----------
+// Метод Кавнара—Тренкла: для тексту будується профіль — список найчастіших
+// символьних триграм слова ("_кі", "кіт", "іт_"), упорядкований за частотою.
+// Профіль порівнюється з профілями мов за відстанню "поза місцем": для
+// кожної триграми рахується різниця рангів, а відсутня в профілі мови
+// триграма отримує максимальний штраф. Перемагає мова з найменшою відстанню.
+//
+// Профілі мов будуються при першому використанні з вбудованих зразків
+// тексту data/lang/<мова>.txt — щоб додати мову, досить додати зразок.
+//
+// Впевненість — наскільки друга за відстанню мова гірша за першу:
+// 1 - d1/d2. Близько 0 — мови практично не розрізнити, понад 0.1 —
+// надійний результат для текстів від кількох речень.
+
+//go:embed data/lang/*.txt
+var langSamples embed.FS
+
+const (
+	langProfileSize = 300 // скільки триграм у профілі
+	langMinTrigrams = 20  // менше — текст закороткий для висновку
+)
+
+var langNames = map[string]string{
+	"uk": "українська",
+	"en": "англійська",
+	"ru": "російська",
+	"pl": "польська",
+}
+
+// Триграма -> ранг (з 0) у профілі
+type langProfile map[string]int
+
+var (
+	langProfilesOnce sync.Once
+	langProfiles     map[string]langProfile
+)
+
+func loadLangProfiles() map[string]langProfile {
+	langProfilesOnce.Do(func() {
+		langProfiles = make(map[string]langProfile)
+		entries, _ := langSamples.ReadDir("data/lang")
+		for _, e := range entries {
+			data, err := langSamples.ReadFile("data/lang/" + e.Name())
+			if err != nil {
+				continue
+			}
+			counts := make(map[string]int)
+			for _, line := range strings.Split(string(data), "\n") {
+				if strings.HasPrefix(line, "#") {
+					continue
+				}
+				addTrigrams(counts, lineWords(line))
+			}
+			lang := strings.TrimSuffix(e.Name(), path.Ext(e.Name()))
+			langProfiles[lang] = rankProfile(counts, langProfileSize)
+		}
+	})
+	return langProfiles
+}
+
+// Додає триграми слів (з межами слова "_") до лічильника
+func addTrigrams(counts map[string]int, words []string) {
+	for _, w := range words {
+		runes := make([]rune, 0, len(w)+2)
+		runes = append(runes, '_')
+		for _, r := range w {
+			if unicode.IsLetter(r) {
+				runes = append(runes, r)
+			}
+		}
+		runes = append(runes, '_')
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+}
+
+// Перші size триграм за спаданням частоти
+func rankProfile(counts map[string]int, size int) langProfile {
+	grams := make([]string, 0, len(counts))
+	for g := range counts {
+		grams = append(grams, g)
+	}
+	sort.Slice(grams, func(i, j int) bool {
+		if counts[grams[i]] != counts[grams[j]] {
+			return counts[grams[i]] > counts[grams[j]]
+		}
+		return grams[i] < grams[j]
+	})
+	if len(grams) > size {
+		grams = grams[:size]
+	}
+	profile := make(langProfile, len(grams))
+	for i, g := range grams {
+		profile[g] = i
+	}
+	return profile
+}
+
+// Результат визначення мови
+type langGuess struct {
+	Lang       string  // код мови; "" — не вдалося визначити
+	Confidence float64 // 0..1
+}
+
+func (g langGuess) String() string {
+	if g.Lang == "" {
+		return "не визначено (замало тексту)"
+	}
+	return fmt.Sprintf("%s (%s), впевненість %.2f", langNames[g.Lang], g.Lang, g.Confidence)
+}
+
+// Визначає мову за частотами триграм тексту
+func detectLanguage(counts map[string]int) langGuess {
+	doc := rankProfile(counts, langProfileSize)
+	if len(doc) < langMinTrigrams {
+		return langGuess{}
+	}
+
+	best, second := "", ""
+	dist := make(map[string]int)
+	for lang, profile := range loadLangProfiles() {
+		d := 0
+		for g, rank := range doc {
+			if r, ok := profile[g]; ok {
+				d += abs(rank - r)
+			} else {
+				d += langProfileSize
+			}
+		}
+		dist[lang] = d
+
+		switch {
+		case best == "" || d < dist[best] || (d == dist[best] && lang < best):
+			best, second = lang, best
+		case second == "" || d < dist[second]:
+			second = lang
+		}
+	}
+
+	guess := langGuess{Lang: best, Confidence: 1}
+	if second != "" && dist[second] > 0 {
+		guess.Confidence = 1 - float64(dist[best])/float64(dist[second])
+	}
+	return guess
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Мова окремого абзацу
+type paragraphLang struct {
+	Line  int // рядок, з якого починається абзац
+	Guess langGuess
+}
+
+// Збирає триграми документа та, за потреби, окремих абзаців
+type langCollector struct {
+	Counts     map[string]int
+	perPara    bool
+	para       map[string]int
+	paraLine   int
+	Paragraphs []paragraphLang
+}
+
+func newLangCollector(perParagraph bool) *langCollector {
+	return &langCollector{Counts: make(map[string]int), perPara: perParagraph}
+}
+
+func (c *langCollector) addLine(lineNo int, line string, tokens []token) {
+	if strings.TrimSpace(line) == "" {
+		c.endParagraph()
+		return
+	}
+
+	var words []string
+	for _, t := range tokens {
+		if t.Kind == tokenWord {
+			words = append(words, t.Text)
+		}
+	}
+	addTrigrams(c.Counts, words)
+
+	if c.perPara {
+		if c.para == nil {
+			c.para = make(map[string]int)
+			c.paraLine = lineNo
+		}
+		addTrigrams(c.para, words)
+	}
+}
+
+// Завершує абзац: визначає його мову
+func (c *langCollector) endParagraph() {
+	if c.para == nil {
+		return
+	}
+	c.Paragraphs = append(c.Paragraphs, paragraphLang{Line: c.paraLine, Guess: detectLanguage(c.para)})
+	c.para = nil
+}
+
+// Виводить мову кожного абзацу
+func writeParagraphLangs(w io.Writer, name string, paragraphs []paragraphLang) {
+	if len(paragraphs) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n--- Мова абзаців: %s ---\n", name)
+	for i, p := range paragraphs {
+		fmt.Fprintf(w, "%3d. рядок %d: %s\n", i+1, p.Line, p.Guess)
+	}
+}
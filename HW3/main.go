@@ -2,129 +2,570 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
-	"unicode/utf8"
+
+	"golanghomeworks/validate"
 )
 
 /*
    === АНАЛІЗАТОР ТЕКСТУ ===
-   Програма дозволяє аналізувати текст, введений користувачем:
+   Програма дозволяє аналізувати текст, введений користувачем,
+   з файлів (шляхи та шаблони) або зі стандартного вводу:
    - Підраховує кількість слів у тексті
    - Знаходить кількість входжень заданого слова (незалежно від регістру)
    - Визначає найдовше слово та його довжину (у символах)
    - Знаходить перше слово, що починається на задану літеру
-   - Підтримує повторний аналіз нового тексту
+   - Шукає слова за шаблоном (точно, префікс, підрядок, регулярний вираз,
+     нечіткий пошук) і показує кожен збіг з позицією та контекстом
+   - Шукає всі форми слова за основою (стемінг для української та англійської)
+   - Визначає мову тексту (uk, en, ru, pl) за профілями триграм — для
+     документа та окремих абзаців
+   - Будує таблиці біграм і триграм та оцінює колокації (PMI, log-likelihood)
+   - Порівнює документи (Jaccard, косинус TF-IDF, MinHash) та показує
+     різницю між двома версіями тексту на рівні слів
+   - Перевіряє орфографію за словниками Hunspell або списками слів і
+     пропонує виправлення
+   - Оцінює тональність речень і документа за словником (з урахуванням
+     заперечень та підсилювачів)
+   - Виділяє ключові слова (TF-IDF, RAKE) та будує стислий виклад з
+     найважливіших речень (TextRank)
+   - Рахує статистику символів: гістограму частот літер, письмо, голосні,
+     великі літери, пари літер; попереджає про змішану розкладку
+   - Транслітерує український текст латиницею за офіційною таблицею 2010
+     року та готує рядки для URL (slug)
+   - Знаходить у тексті email, URL, телефони, IP-адреси, дати та грошові
+     суми (лише валідні — за правилами валідаторів HW2) і маскує їх
+   - Індексує каталог документів (інвертований індекс на диску) і шукає
+     в ньому запитами з AND/OR/NOT, фразами та префіксами, з ранжуванням BM25
+   - Великі файли аналізує паралельно (пул горутин, обмежена пам'ять)
+   - Для кількох файлів показує статистику кожного та сумарну
+   - Підтримує повторний аналіз нового тексту (в інтерактивному режимі)
 */
 
 func main() {
-	for {
-		fmt.Println("=== АНАЛІЗАТОР ТЕКСТУ ===")
-		fmt.Println("Введіть текст для аналізу:")
+	word := flag.String("word", "", "слово для підрахунку входжень")
+	letter := flag.String("letter", "", "літера для пошуку першого слова")
+	stem := flag.String("stem", "", "слово для пошуку всіх його форм (кіт: кота, котові, котів)")
+	search := flag.String("search", "", "шаблон пошуку з виводом конкордансу")
+	mode := flag.String("mode", "exact", "режим пошуку: "+strings.Join(searchModes, ", "))
+	distance := flag.Int("distance", 1, "максимальна відстань Левенштейна для -mode fuzzy")
+	contextWords := flag.Int("context", 5, "скільки слів контексту показувати з кожного боку")
+	lang := flag.Bool("lang", false, "визначити мову тексту")
+	langPar := flag.Bool("lang-paragraphs", false, "визначити мову кожного абзацу")
+	freq := flag.Bool("freq", false, "частотний словник усіх слів")
+	top := flag.Int("top", 20, "скільки найчастіших слів показати (0 — усі)")
+	minLen := flag.Int("min-len", 1, "мінімальна довжина слова у частотному словнику")
+	minCount := flag.Int("min-count", 1, "мінімальна кількість входжень у частотному словнику")
+	stopLangs := flag.String("stopwords", "", "вбудовані стоп-слова через кому: uk,en або auto — за мовою тексту")
+	stopFiles := flag.String("stopwords-file", "", "файли з додатковими стоп-словами через кому")
+	ngrams := flag.String("ngrams", "", "розміри n-грам через кому: 2,3")
+	ngramSort := flag.String("ngram-sort", "count", "сортування n-грам: "+strings.Join(ngramSorts, ", "))
+	compare := flag.Bool("compare", false, "порівняти документи між собою (2 і більше)")
+	spell := flag.String("spell", "", "словники для перевірки орфографії через кому: uk_UA.dic (з .aff поруч) або списки слів")
+	userDict := flag.String("user-dict", "", "файл зі словами, які вважаються правильними (по одному в рядку)")
+	mood := flag.Bool("sentiment", false, "оцінити тональність речень і документа")
+	keywords := flag.Int("keywords", 0, "скільки ключових слів виділити (TF-IDF та RAKE)")
+	summary := flag.Int("summary", 0, "скільки речень залишити у стислому викладі (TextRank)")
+	reference := flag.String("reference", "", "еталонний корпус для IDF ключових слів: файли та шаблони через кому")
+	chars := flag.Bool("chars", false, "статистика символів: частоти літер, письмо, регістр, пари літер")
+	translit := flag.Bool("translit", false, "транслітерувати текст латиницею (таблиця КМУ 2010 р.)")
+	slug := flag.Bool("slug", false, "перетворити кожен рядок на slug для URL")
+	entities := flag.Bool("entities", false, "знайти email, URL, телефони, IP, дати та грошові суми")
+	redact := flag.Bool("redact", false, "вивести текст, замінивши сутності масками ([EMAIL], [PHONE], ...)")
+	entityTypes := flag.String("entity-types", "", "типи сутностей через кому: url,email,ip,date,money,phone (за замовчуванням — усі)")
+	indexDir := flag.String("index", "", "каталог документів для індексування (оновлюється інкрементно)")
+	indexFile := flag.String("index-file", "", "файл індексу (за замовчуванням <каталог>/"+indexName+")")
+	indexExt := flag.String("index-ext", ".txt,.md", "розширення файлів для індексування через кому")
+	query := flag.String("query", "", "пошуковий запит до індексу: слова, \"фраза\", префікс*, AND, OR, NOT, дужки")
+	workers := flag.Int("workers", runtime.NumCPU(), "скільки горутин аналізують текст (1 — послідовно)")
+	format := flag.String("format", "table", "формат частотного словника та n-грам: table, csv, json")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Використання: hw3 [прапорці] [файл | шаблон | -] ...")
+		fmt.Fprintln(os.Stderr, "Без файлів читає стандартний ввід; у терміналі — інтерактивний режим.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var err error
+	opts := options{
+		Word:    normalizeWord(strings.TrimSpace(*word)),
+		Letter:  normalizeWord(strings.TrimSpace(*letter)),
+		Stem:    normalizeWord(strings.TrimSpace(*stem)),
+		Lang:    *lang,
+		LangPar: *langPar,
+		Workers: *workers,
+		Mood:    *mood,
+		Chars:   *chars,
+	}
+	if opts.Stem != "" {
+		opts.StemKey = stemKey(opts.Stem, "")
+	}
+	if *search != "" {
+		opts.Search = *search
+		opts.Context = *contextWords
+		opts.Match, err = newMatcher(searchOptions{Pattern: *search, Mode: *mode, Distance: *distance})
+		if err != nil {
+			fmt.Println("Помилка:", err)
+			os.Exit(2)
+		}
+	}
+
+	if *spell != "" {
+		opts.Spell, err = loadSpellDict(*spell, *userDict)
+		if err != nil {
+			fmt.Println("Помилка завантаження словника:", err)
+			os.Exit(2)
+		}
+	}
+
+	opts.Ngrams, err = parseNgramSizes(*ngrams)
+	if err != nil {
+		fmt.Println("Помилка:", err)
+		os.Exit(2)
+	}
+	if !slices.Contains(ngramSorts, *ngramSort) {
+		fmt.Printf("Помилка: невідоме сортування n-грам: %s (%s)\n", *ngramSort, strings.Join(ngramSorts, ", "))
+		os.Exit(2)
+	}
+
+	freqOpts := freqOptions{
+		Enabled:   *freq,
+		Top:       *top,
+		MinLen:    *minLen,
+		MinCount:  *minCount,
+		Format:    *format,
+		Ngrams:    opts.Ngrams,
+		NgramSort: *ngramSort,
+	}
+	if *freq || len(opts.Ngrams) > 0 {
+		var files []string
+		if *stopFiles != "" {
+			files = strings.Split(*stopFiles, ",")
+		}
+		// auto: список стоп-слів обирається для кожного документа за його мовою
+		langs := strings.Split(*stopLangs, ",")
+		if i := slices.Index(langs, "auto"); i != -1 {
+			langs = slices.Delete(langs, i, i+1)
+			freqOpts.AutoStopwords = true
+			opts.Lang = true
+		}
+		freqOpts.Stopwords, err = loadStopwords(strings.Join(langs, ","), files)
+		if err != nil {
+			fmt.Println("Помилка завантаження стоп-слів:", err)
+			os.Exit(2)
+		}
+	}
+
+	if *indexDir != "" || *query != "" {
+		if !indexCommand(*indexDir, *indexFile, *indexExt, *query, *top) {
+			os.Exit(1)
+		}
+		return
+	}
 
-		reader := bufio.NewReader(os.Stdin)
-		text, err := reader.ReadString('\n')
+	inputs, err := collectInputs(flag.Args())
+	if err != nil {
+		fmt.Println("Помилка:", err)
+		os.Exit(2)
+	}
+
+	if *translit || *slug {
+		if len(inputs) == 0 {
+			inputs = []input{stdinInput()}
+		}
+		if !translitInputs(inputs, *slug) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *entities || *redact {
+		kinds, err := parseEntityKinds(*entityTypes)
 		if err != nil {
-			fmt.Println("Помилка читання:", err)
+			fmt.Println("Помилка:", err)
+			os.Exit(2)
+		}
+		if err := validate.LoadDomainLists(); err != nil {
+			fmt.Println("Помилка завантаження списку доменів:", err)
+			os.Exit(2)
+		}
+		if len(inputs) == 0 {
+			inputs = []input{stdinInput()}
+		}
+		if !entityInputs(inputs, kinds, *entities, *redact) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(inputs) == 0 {
+		if isTerminal(os.Stdin) {
+			interactive()
 			return
 		}
+		inputs = []input{stdinInput()}
+	}
 
-		text = strings.TrimSpace(text) // Видаляємо зайві пробіли та \n
+	if *compare {
+		if !compareInputs(inputs) {
+			os.Exit(1)
+		}
+		return
+	}
 
-		// Перевірка на порожній текст
-		if len(text) == 0 {
-			fmt.Println("Текст порожній! Спробуйте ще раз.")
+	if *keywords > 0 || *summary > 0 {
+		var files []string
+		if *stopFiles != "" {
+			files = strings.Split(*stopFiles, ",")
+		}
+		if !summarizeInputs(inputs, *reference, files, *keywords, *summary) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Ctrl+C зупиняє аналіз великих файлів
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if !analyzeInputs(ctx, inputs, opts, freqOpts) {
+		stop()
+		os.Exit(1)
+	}
+}
+
+// Аналізує всі джерела, друкує статистику кожного та сумарну.
+// Повертає false, якщо хоча б одне джерело не вдалося прочитати.
+func analyzeInputs(ctx context.Context, inputs []input, opts options, freqOpts freqOptions) bool {
+	ok := true
+	total := textStats{Name: "Разом"}
+	var reports []freqReport
+	var ngramReports []ngramReport
+
+	// CSV та JSON призначені для інших програм, тому виводимо лише їх
+	machine := (freqOpts.Enabled || len(freqOpts.Ngrams) > 0) && freqOpts.Format != "table"
+
+	for _, in := range inputs {
+		stats, err := analyzeInput(ctx, in, opts)
+		if ctx.Err() != nil {
+			fmt.Println("Аналіз перервано.")
+			return false
+		}
+		if err != nil {
+			fmt.Printf("Помилка читання %s: %v\n", in.Name, err)
+			ok = false
 			continue
 		}
 
-		text = strings.ToLower(text) // Переводимо текст у нижній регістр
+		docOpts := freqOpts.forText(stats)
+		if freqOpts.Enabled {
+			reports = append(reports, buildFreqReport(stats, docOpts))
+		}
+		grams := buildNgramReports(stats, docOpts)
+		ngramReports = append(ngramReports, grams...)
+		if !machine {
+			if len(inputs) > 1 {
+				fmt.Printf("\n=== %s ===\n", in.Name)
+			}
+			printStats(stats, opts)
+			writeConcordance(os.Stdout, in.Name, stats.Matches)
+			writeParagraphLangs(os.Stdout, in.Name, stats.ParagraphLangs)
+			writeMisspellings(os.Stdout, in.Name, stats.Misspelled, opts.Spell, stats.Freq)
+			if opts.Mood {
+				writeSentiment(os.Stdout, in.Name, stats.SentenceMoods, stats.MoodWords, true)
+			}
+			if opts.Chars {
+				writeCharStats(os.Stdout, in.Name, stats.Chars)
+			}
+			if freqOpts.Enabled {
+				writeFreqTable(os.Stdout, reports[len(reports)-1])
+			}
+			writeNgramReports(os.Stdout, grams, "table")
+		}
+		total.merge(stats)
+	}
+
+	if len(inputs) > 1 {
+		totalOpts := freqOpts.forText(total)
+		if freqOpts.Enabled {
+			reports = append(reports, buildFreqReport(total, totalOpts))
+		}
+		grams := buildNgramReports(total, totalOpts)
+		ngramReports = append(ngramReports, grams...)
+		if !machine {
+			fmt.Println("\n=== Разом ===")
+			printStats(total, opts)
+			if opts.Mood {
+				writeSentiment(os.Stdout, total.Name, total.SentenceMoods, total.MoodWords, false)
+			}
+			if opts.Chars {
+				writeCharStats(os.Stdout, total.Name, total.Chars)
+			}
+			if freqOpts.Enabled {
+				writeFreqTable(os.Stdout, reports[len(reports)-1])
+			}
+			writeNgramReports(os.Stdout, grams, "table")
+		}
+	}
+
+	if machine {
+		if freqOpts.Enabled {
+			if err := writeFreqReports(os.Stdout, reports, freqOpts.Format); err != nil {
+				fmt.Fprintln(os.Stderr, "Помилка:", err)
+				return false
+			}
+		}
+		if len(freqOpts.Ngrams) > 0 {
+			if err := writeNgramReports(os.Stdout, ngramReports, freqOpts.Format); err != nil {
+				fmt.Fprintln(os.Stderr, "Помилка:", err)
+				return false
+			}
+		}
+	}
+	return ok
+}
+
+// Режим порівняння: читає всі документи і виводить їхню схожість
+func compareInputs(inputs []input) bool {
+	if len(inputs) < 2 {
+		fmt.Println("Для порівняння потрібно щонайменше два документи.")
+		return false
+	}
+	var docs []document
+	for _, in := range inputs {
+		doc, err := readDocument(in)
+		if err != nil {
+			fmt.Printf("Помилка читання %s: %v\n", in.Name, err)
+			return false
+		}
+		docs = append(docs, doc)
+	}
+	compareDocuments(os.Stdout, docs, isTerminal(os.Stdout))
+	return true
+}
+
+// Режим індексу: оновлює індекс каталогу та/або виконує запит
+func indexCommand(dir, file, exts, query string, top int) bool {
+	if dir != "" {
+		// Абсолютний шлях — щоб запити працювали з будь-якого каталогу
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			fmt.Println("Помилка:", err)
+			return false
+		}
+		dir = abs
+	}
+	if file == "" {
+		if dir == "" {
+			fmt.Println("Вкажіть каталог (-index) або файл індексу (-index-file).")
+			return false
+		}
+		file = filepath.Join(dir, indexName)
+	}
+	idx, err := loadIndex(file, dir)
+	if err != nil {
+		fmt.Println("Помилка:", err)
+		return false
+	}
 
-		// Замінюємо всі розділові знаки на пробіли
-		punctuations := ",.!?;:-\"'()[]{}/\\|+="
-		cleanedText := ""
-		for _, r := range text {
-			if strings.ContainsRune(punctuations, r) {
-				cleanedText += " "
-			} else {
-				cleanedText += string(r)
+	if dir != "" {
+		var extList []string
+		for _, e := range strings.Split(exts, ",") {
+			if e = strings.ToLower(strings.TrimSpace(e)); e != "" {
+				if !strings.HasPrefix(e, ".") {
+					e = "." + e
+				}
+				extList = append(extList, e)
+			}
+		}
+		res, err := idx.update(extList, file)
+		if err != nil {
+			fmt.Println("Помилка індексування:", err)
+			return false
+		}
+		if res.Added+res.Updated+res.Removed > 0 {
+			if err := idx.save(file); err != nil {
+				fmt.Println("Помилка збереження індексу:", err)
+				return false
 			}
 		}
-		text = cleanedText
+		fmt.Printf("Індекс %s: документів %d, слів у словнику %d (додано %d, оновлено %d, видалено %d, без змін %d)\n",
+			file, len(idx.Docs), len(idx.Postings), res.Added, res.Updated, res.Removed, res.Unchanged)
+	}
+
+	if query == "" {
+		return true
+	}
+	hits, total, terms, err := idx.search(query, top)
+	if err != nil {
+		fmt.Println("Помилка запиту:", err)
+		return false
+	}
+	writeSearchHits(os.Stdout, idx, query, hits, total, terms, isTerminal(os.Stdout))
+	return true
+}
+
+// Режим ключових слів і стислого викладу
+func summarizeInputs(inputs []input, reference string, stopFiles []string, keywords, summary int) bool {
+	stop, err := loadStopwords("uk,en", stopFiles)
+	if err != nil {
+		fmt.Println("Помилка завантаження стоп-слів:", err)
+		return false
+	}
+
+	var ref referenceCorpus
+	if reference != "" {
+		refInputs, err := collectInputs(strings.Split(reference, ","))
+		if err == nil {
+			ref, err = loadReferenceCorpus(refInputs)
+		}
+		if err != nil {
+			fmt.Println("Помилка читання еталонного корпусу:", err)
+			return false
+		}
+	}
 
-		// Запитуємо слово для пошуку
-		fmt.Print("Введіть слово або літеру для пошуку: ")
-		wordR, err := reader.ReadString('\n')
+	ok := true
+	for _, in := range inputs {
+		sentences, err := readSentences(in)
 		if err != nil {
-			fmt.Println("Помилка читання:", err)
-			return
+			fmt.Printf("Помилка читання %s: %v\n", in.Name, err)
+			ok = false
+			continue
+		}
+		writeSummary(os.Stdout, in.Name, sentences, stop, ref, keywords, summary)
+	}
+	return ok
+}
+
+// Режим транслітерації: виводить текст усіх джерел латиницею
+func translitInputs(inputs []input, slug bool) bool {
+	ok := true
+	for _, in := range inputs {
+		r, err := in.Open()
+		if err == nil {
+			err = writeTransliteration(os.Stdout, r, slug)
+			r.Close()
 		}
-		word := strings.TrimSpace(strings.ToLower(wordR)) // Видаляємо пробіли та \n
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка читання %s: %v\n", in.Name, err)
+			ok = false
+		}
+	}
+	return ok
+}
 
-		// Розбиваємо текст на слова
-		words := strings.Fields(text)
+// Режим сутностей: звіт про знайдені сутності та/або текст з масками.
+// Якщо потрібне і те, і інше, звіт виводиться в stderr, щоб не змішуватися
+// з текстом.
+func entityInputs(inputs []input, kinds []entityKind, report, redact bool) bool {
+	var text io.Writer
+	reportTo := io.Writer(os.Stdout)
+	if redact {
+		text = os.Stdout
+		reportTo = os.Stderr
+	}
 
-		// Перевірка на порожній список слів
-		if len(words) == 0 {
-			fmt.Println("У тексті немає слів для аналізу.")
+	ok := true
+	for _, in := range inputs {
+		r, err := in.Open()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка читання %s: %v\n", in.Name, err)
+			ok = false
+			continue
+		}
+		found, err := scanEntities(r, kinds, text)
+		r.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка читання %s: %v\n", in.Name, err)
+			ok = false
 			continue
 		}
+		if report {
+			writeEntities(reportTo, in.Name, found, kinds)
+		}
+	}
+	return ok
+}
+
+func analyzeInput(ctx context.Context, in input, opts options) (textStats, error) {
+	r, err := in.Open()
+	if err != nil {
+		return textStats{}, err
+	}
+	defer r.Close()
+	if opts.Workers > 1 {
+		return analyzeParallel(ctx, in.Name, r, opts, opts.Workers)
+	}
+	return analyze(in.Name, r, opts)
+}
+
+// Інтерактивний режим: текст вводиться з клавіатури, доки не буде
+// введено порожній рядок
+func interactive() {
+	reader := bufio.NewReader(os.Stdin)
+	readLine := func(prompt string) (string, bool) {
+		fmt.Print(prompt)
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return "", false
+		}
+		return strings.TrimSpace(line), true
+	}
 
-		var largestWord = ""
-		var count int
+	for {
+		fmt.Println("=== АНАЛІЗАТОР ТЕКСТУ ===")
+		fmt.Println("Введіть текст для аналізу (порожній рядок — кінець вводу):")
 
-		// Проходимо по кожному слову:
-		for _, w := range words {
-			// Рахуємо кількість входжень шуканого слова/літери
-			if w == word {
-				count++
+		var lines []string
+		eof := false
+		for {
+			line, ok := readLine("")
+			if !ok {
+				eof = true
+				break
 			}
-			// Шукаємо найдовше слово (за кількістю символів)
-			if utf8.RuneCountInString(w) > utf8.RuneCountInString(largestWord) {
-				largestWord = w
+			if line == "" {
+				break
 			}
+			lines = append(lines, line)
 		}
+		text := strings.Join(lines, "\n")
 
-		// Вивід результатів пошуку слова
-		if word == "" {
-			fmt.Println("Слово для пошуку не введено.")
-		} else if count == 0 {
-			fmt.Printf("Слово або літера \"%s\" не знайдено у тексті.\n", strings.TrimSpace(wordR))
-		} else {
-			fmt.Printf("Слово або літера \"%s\" зустрічається %d раз(и).\n", strings.TrimSpace(wordR), count)
-		}
-
-		// Вивід загальної кількості слів та найдовшого слова
-		fmt.Printf("У тексті всього %d слів.\n", len(words))
-		fmt.Printf("Найдовше слово тексту: %s (%d символів)\n", largestWord, utf8.RuneCountInString(largestWord))
-
-		// Запитуємо літеру для пошуку першого слова, що починається з неї
-		var letter string
-		fmt.Println("Введіть літеру для пошуку першого слова:")
-		fmt.Scanln(&letter)
-		letter = strings.ToLower(strings.TrimSpace(letter))
-
-		var firstLetterWord string
-		for _, w := range words {
-			// Перевіряємо, чи слово починається на введену літеру
-			if len(w) > 0 && letter == string([]rune(w)[0]) {
-				firstLetterWord = w
-				break // Знайшли перше слово — виходимо з циклу
+		// Перевірка на порожній текст
+		if len(text) == 0 {
+			if eof {
+				return
 			}
+			fmt.Println("Текст порожній! Спробуйте ще раз.")
+			continue
 		}
-		if letter == "" {
+
+		// Запитуємо слово для пошуку та літеру для пошуку першого слова
+		word, _ := readLine("Введіть слово або літеру для пошуку: ")
+		letter, _ := readLine("Введіть літеру для пошуку першого слова: ")
+		opts := options{Word: normalizeWord(word), Letter: normalizeWord(letter)}
+
+		stats, _ := analyze("", strings.NewReader(text), opts)
+		printStats(stats, opts)
+		if opts.Word == "" {
+			fmt.Println("Слово для пошуку не введено.")
+		}
+		if opts.Letter == "" {
 			fmt.Println("Літера для пошуку не введена.")
-		} else if firstLetterWord == "" {
-			fmt.Printf("Слів, що починаються на \"%s\", не знайдено.\n", letter)
-		} else {
-			fmt.Printf("Перше слово, що починається на \"%s\": %s\n", letter, firstLetterWord)
 		}
 
 		// Запит на повторний аналіз
-		var choice string
-		fmt.Println("Хочете проаналізувати інший текст? (yes/no)")
-		fmt.Scanln(&choice)
-
+		choice, _ := readLine("Хочете проаналізувати інший текст? (yes/no)\n")
 		switch strings.ToLower(choice) {
 		case "yes":
 			continue
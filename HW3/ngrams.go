@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------- N-грами та колокації ----------
+// N-грама — послідовність із n слів підряд, між якими немає розділових
+// знаків, чисел чи порожнього рядка: "кіт, пес" не дає біграми "кіт пес".
+// Перенос рядка фразу не розриває.
+//
+// Міри зв'язаності (маргінальні частоти рахуються за позицією слова в n-грамах):
+// - PMI — log2(P(xy) / (P(x)·P(y))): наскільки частіше слова трапляються
+//   разом, ніж випадково; завищена для рідкісних сполучень, тож варто
+//   відсікати їх через -min-count;
+// - log-likelihood (G², Даннінг) — статистика таблиці спряженості 2×2:
+//   стійка до рідкісних подій, добре виділяє сталі вирази.
+// Для триграм таблиця будується для пари (xy, z).
+
+var ngramSorts = []string{"count", "pmi", "llr"}
+
+// Збирає n-грами з потоку токенів
+type ngramCounter struct {
+	sizes  []int
+	window []string // останні слова поточної фрази
+	Counts map[int]map[string]int
+}
+
+func newNgramCounter(sizes []int) *ngramCounter {
+	c := &ngramCounter{sizes: sizes, Counts: make(map[int]map[string]int)}
+	for _, n := range sizes {
+		c.Counts[n] = make(map[string]int)
+	}
+	return c
+}
+
+// Розбирає список розмірів n-грам: "2,3"
+func parseNgramSizes(value string) ([]int, error) {
+	var sizes []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 2 || n > 3 {
+			return nil, fmt.Errorf("підтримуються біграми та триграми (2, 3), отримано %q", part)
+		}
+		sizes = append(sizes, n)
+	}
+	return sizes, nil
+}
+
+// Враховує рядок тексту. Порожній рядок або знак між словами розриває фразу.
+func (c *ngramCounter) addLine(line string, tokens []token) {
+	runes := []rune(line)
+	pos := 0 // індекс символу після попереднього токена
+	for _, t := range tokens {
+		if hasPunct(runes[pos : t.Col-1]) {
+			c.window = c.window[:0]
+		}
+		pos = t.Col - 1 + utf8.RuneCountInString(t.Raw)
+
+		if t.Kind != tokenWord {
+			c.window = c.window[:0]
+			continue
+		}
+		c.add(t.Text)
+	}
+	if hasPunct(runes[pos:]) || len(tokens) == 0 {
+		c.window = c.window[:0]
+	}
+}
+
+func hasPunct(gap []rune) bool {
+	for _, r := range gap {
+		if !unicode.IsSpace(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ngramCounter) add(word string) {
+	maxSize := 0
+	for _, n := range c.sizes {
+		maxSize = max(maxSize, n)
+	}
+	if len(c.window) == maxSize {
+		c.window = append(c.window[:0], c.window[1:]...)
+	}
+	c.window = append(c.window, word)
+
+	for _, n := range c.sizes {
+		if len(c.window) >= n {
+			c.Counts[n][strings.Join(c.window[len(c.window)-n:], " ")]++
+		}
+	}
+}
+
+// Рядок звіту n-грам
+type ngramFreq struct {
+	Ngram string  `json:"ngram"`
+	Count int     `json:"count"`
+	PMI   float64 `json:"pmi"`
+	LLR   float64 `json:"llr"`
+}
+
+// Звіт n-грам одного розміру для одного джерела
+type ngramReport struct {
+	Name  string      `json:"name"`
+	N     int         `json:"n"`
+	Total int         `json:"total"`
+	Items []ngramFreq `json:"ngrams"`
+}
+
+// Будує звіти для всіх увімкнених розмірів n-грам
+func buildNgramReports(stats textStats, opts freqOptions) []ngramReport {
+	var reports []ngramReport
+	for _, n := range opts.Ngrams {
+		reports = append(reports, buildNgramReport(stats.Name, n, stats.Ngrams[n], opts))
+	}
+	return reports
+}
+
+// Будує звіт для n-грам розміру n: фільтрує, рахує PMI та G², сортує
+func buildNgramReport(name string, n int, counts map[string]int, opts freqOptions) ngramReport {
+	report := ngramReport{Name: name, N: n, Items: []ngramFreq{}}
+
+	// Маргінальні частоти: "голова" (перші n-1 слів) та останнє слово
+	heads := make(map[string]int)
+	tails := make(map[string]int)
+	// Частоти слів на кожній позиції — для PMI
+	positions := make([]map[string]int, n)
+	for i := range positions {
+		positions[i] = make(map[string]int)
+	}
+	for gram, count := range counts {
+		words := strings.Split(gram, " ")
+		heads[strings.Join(words[:n-1], " ")] += count
+		tails[words[n-1]] += count
+		for i, w := range words {
+			positions[i][w] += count
+		}
+		report.Total += count
+	}
+	total := float64(report.Total)
+
+	for gram, count := range counts {
+		if count < opts.MinCount {
+			continue
+		}
+		words := strings.Split(gram, " ")
+		if opts.Stopwords[words[0]] || opts.Stopwords[words[n-1]] {
+			continue
+		}
+
+		pmi := math.Log2(float64(count)) + float64(n-1)*math.Log2(total)
+		for i, w := range words {
+			pmi -= math.Log2(float64(positions[i][w]))
+		}
+
+		head := strings.Join(words[:n-1], " ")
+		k11 := float64(count)
+		k12 := float64(heads[head]) - k11
+		k21 := float64(tails[words[n-1]]) - k11
+		k22 := total - k11 - k12 - k21
+
+		report.Items = append(report.Items, ngramFreq{
+			Ngram: gram,
+			Count: count,
+			PMI:   pmi,
+			LLR:   logLikelihood(k11, k12, k21, k22),
+		})
+	}
+
+	sortNgrams(report.Items, opts.NgramSort)
+	if opts.Top > 0 && len(report.Items) > opts.Top {
+		report.Items = report.Items[:opts.Top]
+	}
+	return report
+}
+
+// G² для таблиці спряженості 2×2 (Dunning, 1993)
+func logLikelihood(k11, k12, k21, k22 float64) float64 {
+	total := k11 + k12 + k21 + k22
+	rows := [2]float64{k11 + k12, k21 + k22}
+	cols := [2]float64{k11 + k21, k12 + k22}
+	cells := [2][2]float64{{k11, k12}, {k21, k22}}
+
+	g := 0.0
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if k := cells[i][j]; k > 0 {
+				expected := rows[i] * cols[j] / total
+				g += k * math.Log(k/expected)
+			}
+		}
+	}
+	return 2 * g
+}
+
+// Сортування за вибраною мірою (за спаданням), при рівності — за алфавітом
+func sortNgrams(items []ngramFreq, by string) {
+	key := func(f ngramFreq) float64 {
+		switch by {
+		case "pmi":
+			return f.PMI
+		case "llr":
+			return f.LLR
+		}
+		return float64(f.Count)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if a, b := key(items[i]), key(items[j]); a != b {
+			return a > b
+		}
+		return items[i].Ngram < items[j].Ngram
+	})
+}
+
+// Виводить звіти n-грам у вибраному форматі
+func writeNgramReports(w io.Writer, reports []ngramReport, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(reports)
+
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"source", "n", "ngram", "count", "pmi", "llr"})
+		for _, r := range reports {
+			for _, f := range r.Items {
+				writer.Write([]string{
+					r.Name, strconv.Itoa(r.N), f.Ngram, strconv.Itoa(f.Count),
+					strconv.FormatFloat(f.PMI, 'f', 3, 64), strconv.FormatFloat(f.LLR, 'f', 3, 64),
+				})
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "table", "":
+		for _, r := range reports {
+			writeNgramTable(w, r)
+		}
+		return nil
+	}
+	return fmt.Errorf("невідомий формат: %s (table, csv, json)", format)
+}
+
+func writeNgramTable(w io.Writer, r ngramReport) {
+	title := map[int]string{2: "Біграми", 3: "Триграми"}[r.N]
+	fmt.Fprintf(w, "\n--- %s: %s (усього: %d) ---\n", title, r.Name, r.Total)
+	if len(r.Items) == 0 {
+		fmt.Fprintln(w, "Немає n-грам, що відповідають фільтрам.")
+		return
+	}
+
+	width := utf8.RuneCountInString("Фраза")
+	for _, f := range r.Items {
+		width = max(width, utf8.RuneCountInString(f.Ngram))
+	}
+	fmt.Fprintf(w, "%4s  %s  %s  %7s  %s\n", "#", padRight("Фраза", width), padLeft("Кількість", 9), "PMI", padLeft("G²", 9))
+	for i, f := range r.Items {
+		fmt.Fprintf(w, "%4d  %s  %9d  %7.2f  %9.2f\n", i+1, padRight(f.Ngram, width), f.Count, f.PMI, f.LLR)
+	}
+}
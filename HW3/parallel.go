@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+)
+
+// ---------- Паралельний аналіз великих текстів ----------
+// Схема map-reduce:
+// - читач ділить потік на фрагменти приблизно по chunkSize байтів, розрізаючи
+//   по порожніх рядках (межах абзаців), щоб речення, n-грами та контекст
+//   пошуку не розривались; абзац, довший за maxChunkSize, ріжеться по рядку;
+// - пул воркерів аналізує фрагменти незалежно (lineAnalyzer). Виняток —
+//   фрагменти розрізаного абзацу: вони продовжують попередній фрагмент і
+//   аналізуються по черзі одним спільним lineAnalyzer (абзац не рахується
+//   вдруге, відкрите речення, n-грами та контекст переходять через межу),
+//   а результат усього абзацу віддає його останній фрагмент;
+// - часткові результати зливаються строго в порядку фрагментів, тож "перше
+//   слово на літеру", номери рядків і порядок збігів такі самі, як при
+//   послідовному аналізі.
+// Пам'ять обмежена: одночасно існує не більше 2*workers фрагментів разом із
+// їхніми частковими результатами. Аналіз зупиняється при скасуванні ctx.
+
+const (
+	chunkSize    = 1 << 20 // 1 МБ
+	maxChunkSize = 4 << 20
+)
+
+// Фрагмент тексту для воркера
+type textChunk struct {
+	index     int
+	firstLine int // скільки рядків було до фрагмента
+	offset    int // скільки байтів було до фрагмента
+	text      string
+
+	// Для розрізаного абзацу: спільний аналізатор, сигнал завершення
+	// попереднього фрагмента та власний. open — абзац триває далі.
+	group *chunkGroup
+	after <-chan struct{}
+	done  chan struct{}
+	open  bool
+}
+
+// Спільний стан фрагментів одного розрізаного абзацу
+type chunkGroup struct {
+	analyzer *lineAnalyzer
+}
+
+type chunkResult struct {
+	index int
+	stats textStats
+}
+
+// Аналізує текст паралельно у workers горутинах
+func analyzeParallel(ctx context.Context, name string, r io.Reader, opts options, workers int) (textStats, error) {
+	workers = max(workers, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan textChunk)
+	results := make(chan chunkResult)
+	// Слот займає читач перед відправкою фрагмента і звільняє злиття
+	slots := make(chan struct{}, 2*workers)
+
+	var readErr error
+	go func() {
+		defer close(chunks)
+		readErr = readChunks(ctx, r, slots, chunks)
+		if readErr != nil {
+			cancel()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				if ctx.Err() != nil {
+					continue // дочитуємо канал, щоб читач не заблокувався
+				}
+				res := chunkResult{index: c.index, stats: analyzeChunk(ctx, name, c, opts)}
+				select {
+				case results <- res:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Злиття по порядку: результати, що прийшли раніше, чекають своєї черги
+	total := textStats{Name: name}
+	pending := make(map[int]textStats)
+	next := 0
+	for res := range results {
+		pending[res.index] = res.stats
+		for {
+			stats, ok := pending[next]
+			if !ok {
+				break
+			}
+			total.merge(stats)
+			delete(pending, next)
+			next++
+			<-slots
+		}
+	}
+
+	if readErr != nil {
+		return total, readErr
+	}
+	return total, ctx.Err()
+}
+
+// Читає потік і ділить його на фрагменти
+func readChunks(ctx context.Context, r io.Reader, slots chan struct{}, chunks chan<- textChunk) error {
+	reader := bufio.NewReader(r)
+	var sb strings.Builder
+	chunk := textChunk{}
+
+	var prevDone chan struct{} // кінець попереднього фрагмента розрізаного абзацу
+
+	send := func(open bool) bool {
+		chunk.text = sb.String()
+		chunk.open = open
+		if open && chunk.group == nil {
+			chunk.group = &chunkGroup{}
+		}
+		if chunk.group != nil {
+			chunk.after, chunk.done = prevDone, make(chan struct{})
+			prevDone = chunk.done
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+			return false
+		}
+		next := textChunk{
+			index:     chunk.index + 1,
+			firstLine: chunk.firstLine + strings.Count(chunk.text, "\n"),
+			offset:    chunk.offset + len(chunk.text),
+		}
+		if open {
+			next.group = chunk.group
+		} else {
+			prevDone = nil
+		}
+		chunk = next
+		sb = strings.Builder{}
+		return true
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			sb.WriteString(line)
+			blank := strings.TrimSpace(line) == ""
+			if (sb.Len() >= chunkSize && blank) || sb.Len() >= maxChunkSize {
+				if !send(!blank) {
+					return ctx.Err()
+				}
+			}
+		}
+		if err == io.EOF {
+			if sb.Len() > 0 && !send(false) {
+				return ctx.Err()
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Аналізує один фрагмент. Фрагмент розрізаного абзацу чекає на попередній
+// і продовжує його аналізатор; поки абзац не закінчився, результат порожній.
+func analyzeChunk(ctx context.Context, name string, c textChunk, opts options) textStats {
+	var a *lineAnalyzer
+	if c.group == nil {
+		a = newLineAnalyzer(name, opts, c.firstLine, c.offset)
+	} else {
+		if c.after != nil {
+			select {
+			case <-c.after:
+			case <-ctx.Done():
+				return textStats{}
+			}
+		}
+		defer close(c.done)
+		if c.group.analyzer == nil {
+			c.group.analyzer = newLineAnalyzer(name, opts, c.firstLine, c.offset)
+		}
+		a = c.group.analyzer
+	}
+
+	text := c.text
+	for len(text) > 0 {
+		end := strings.IndexByte(text, '\n') + 1
+		if end == 0 {
+			end = len(text)
+		}
+		a.addLine(text[:end])
+		text = text[end:]
+	}
+	if c.open {
+		return textStats{}
+	}
+	return a.finish()
+}
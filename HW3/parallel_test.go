@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// Корпус для тестів і бенчмарків: абзаци зі зразків мов, повторені до
+// потрібного розміру
+var (
+	corpusMu    sync.Mutex
+	corpusCache = make(map[int][]byte)
+)
+
+func testCorpus(tb testing.TB, size int) []byte {
+	tb.Helper()
+	corpusMu.Lock()
+	defer corpusMu.Unlock()
+	if data, ok := corpusCache[size]; ok {
+		return data
+	}
+
+	var paragraphs []string
+	for _, lang := range []string{"uk", "en", "ru", "pl"} {
+		data, err := os.ReadFile("data/lang/" + lang + ".txt")
+		if err != nil {
+			tb.Fatal(err)
+		}
+		for _, p := range strings.Split(string(data), "\n\n") {
+			if p = strings.TrimSpace(p); p != "" && !strings.HasPrefix(p, "#") {
+				paragraphs = append(paragraphs, p)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(size + 4096)
+	for i := 0; buf.Len() < size; i++ {
+		// Простий псевдовипадковий порядок, щоб фрагменти відрізнялися
+		buf.WriteString(paragraphs[(i*7+i/len(paragraphs))%len(paragraphs)])
+		buf.WriteString("\n\n")
+	}
+	corpusCache[size] = buf.Bytes()
+	return corpusCache[size]
+}
+
+// Початковий алгоритм програми: очищення тексту через cleanedText += ...,
+// потім strings.Fields. Залишений лише для порівняння швидкості.
+func legacyAnalyze(text, word, letter string) (words, count int, longest, first string) {
+	text = strings.ToLower(text)
+	punctuations := ",.!?;:-\"'()[]{}/\\|+="
+	cleanedText := ""
+	for _, r := range text {
+		if strings.ContainsRune(punctuations, r) {
+			cleanedText += " "
+		} else {
+			cleanedText += string(r)
+		}
+	}
+	for _, w := range strings.Fields(cleanedText) {
+		words++
+		if w == word {
+			count++
+		}
+		if utf8.RuneCountInString(w) > utf8.RuneCountInString(longest) {
+			longest = w
+		}
+		if first == "" && strings.HasPrefix(w, letter) {
+			first = w
+		}
+	}
+	return
+}
+
+func TestAnalyzeParallelMatchesSequential(t *testing.T) {
+	data := testCorpus(t, 3*chunkSize+12345)
+	matcher, err := newMatcher(searchOptions{Pattern: "кот", Mode: "prefix"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := options{
+		Word:    "the",
+		Letter:  "ж",
+		Stem:    "кіт",
+		StemKey: stemKey("кіт", ""),
+		Search:  "кот",
+		Match:   matcher,
+		Context: 3,
+		Ngrams:  []int{2, 3},
+		Lang:    true,
+		LangPar: true,
+		Mood:    true,
+		Chars:   true,
+	}
+
+	want, err := analyze("corpus", bytes.NewReader(data), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, workers := range []int{1, 2, 4, 8} {
+		got, err := analyzeParallel(context.Background(), "corpus", bytes.NewReader(data), opts, workers)
+		if err != nil {
+			t.Fatalf("workers=%d: %v", workers, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("workers=%d: результат відрізняється від послідовного аналізу: "+
+				"слів %d/%d, рядків %d/%d, речень %d/%d, збігів %d/%d",
+				workers, got.Words, want.Words, got.Lines, want.Lines,
+				got.Shape.Sentences, want.Shape.Sentences, len(got.Matches), len(want.Matches))
+		}
+	}
+}
+
+// Абзац, довший за maxChunkSize, ріжеться посередині: фрагменти-продовження
+// не мають додавати абзаців і речень, а n-грами й контекст — рватися
+func TestAnalyzeParallelLongParagraph(t *testing.T) {
+	if testing.Short() {
+		t.Skip("великий корпус пропускається з -short")
+	}
+	long := bytes.ReplaceAll(testCorpus(t, maxChunkSize+chunkSize/2), []byte("\n\n"), []byte("\n"))
+	data := append(append(long, "\n\n"...), testCorpus(t, chunkSize/2)...)
+	matcher, err := newMatcher(searchOptions{Pattern: "кот", Mode: "prefix"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := options{
+		Letter:  "ж",
+		Match:   matcher,
+		Context: 3,
+		Ngrams:  []int{2, 3},
+		LangPar: true,
+		Mood:    true,
+	}
+
+	want, err := analyze("long", bytes.NewReader(data), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, workers := range []int{2, 4} {
+		got, err := analyzeParallel(context.Background(), "long", bytes.NewReader(data), opts, workers)
+		if err != nil {
+			t.Fatalf("workers=%d: %v", workers, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("workers=%d: результат відрізняється від послідовного аналізу: "+
+				"речень %d/%d, абзаців %d/%d, збігів %d/%d",
+				workers, got.Shape.Sentences, want.Shape.Sentences,
+				got.Shape.Paragraphs, want.Shape.Paragraphs, len(got.Matches), len(want.Matches))
+		}
+	}
+}
+
+func TestAnalyzeParallelCancel(t *testing.T) {
+	data := testCorpus(t, 8*chunkSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stats, err := analyzeParallel(ctx, "corpus", bytes.NewReader(data), options{}, 4)
+	if err != context.Canceled {
+		t.Fatalf("очікувалась помилка context.Canceled, отримано %v", err)
+	}
+	if stats.Words >= 1000000 {
+		t.Errorf("після скасування проаналізовано надто багато: %d слів", stats.Words)
+	}
+}
+
+// Розміри корпусу для бенчмарків. 100 МБ пропускається з -short.
+var benchSizes = []int{1 << 20, 10 << 20, 100 << 20}
+
+func sizeName(size int) string {
+	return fmt.Sprintf("%dMB", size>>20)
+}
+
+// Початковий алгоритм квадратичний: на 1 МБ він працює хвилини, на 100 МБ —
+// години, тож для нього беремо малі розміри, щоб було видно ріст часу.
+func BenchmarkLegacy(b *testing.B) {
+	for _, size := range []int{16 << 10, 64 << 10, 256 << 10} {
+		b.Run(fmt.Sprintf("%dKB", size>>10), func(b *testing.B) {
+			text := string(testCorpus(b, size))
+			b.SetBytes(int64(len(text)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				legacyAnalyze(text, "кіт", "ж")
+			}
+		})
+	}
+}
+
+func BenchmarkSequential(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(sizeName(size), func(b *testing.B) {
+			if size > 10<<20 && testing.Short() {
+				b.Skip("великий корпус пропускається з -short")
+			}
+			data := testCorpus(b, size)
+			b.SetBytes(int64(len(data)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := analyze("corpus", bytes.NewReader(data), options{Word: "кіт", Letter: "ж"}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParallel(b *testing.B) {
+	for _, size := range benchSizes {
+		for _, workers := range []int{2, 4, 8} {
+			b.Run(fmt.Sprintf("%s/workers=%d", sizeName(size), workers), func(b *testing.B) {
+				if size > 10<<20 && testing.Short() {
+					b.Skip("великий корпус пропускається з -short")
+				}
+				data := testCorpus(b, size)
+				b.SetBytes(int64(len(data)))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, err := analyzeParallel(context.Background(), "corpus", bytes.NewReader(data),
+						options{Word: "кіт", Letter: "ж"}, workers)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
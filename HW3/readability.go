@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------- Читабельність та структура тексту ----------
+// Речення закінчується на . ! ? … (послідовність знаків — один кінець),
+// а також на порожньому рядку: заголовки часто пишуть без крапки.
+// Абзаци розділяються порожніми рядками.
+//
+// Індекси читабельності (ASL — слів у реченні, ASW — складів у слові):
+// - англійська, Флеш:           206.835 - 1.015*ASL - 84.6*ASW
+// - українська, адаптація Флеша
+//   для слов'янських мов
+//   (коефіцієнти Оборнєвої):    206.835 - 1.3*ASL - 60.1*ASW
+// Шкала однакова: 100 — дуже легко, 0 і нижче — дуже складно.
+
+// Ознаки структури тексту, що накопичуються порядково
+type textShape struct {
+	Sentences     int
+	Paragraphs    int
+	Chars         int // символів з пробілами (без переносів рядків)
+	CharsNoSpaces int
+	Letters       int // літер у словах (без апострофів і дефісів)
+	Syllables     int
+	CyrillicWords int // для вибору формули читабельності
+
+	inSentence  bool // від кінця попереднього речення вже були слова
+	inParagraph bool
+}
+
+func isSentenceEnd(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…'
+}
+
+// Враховує рядок тексту та його токени
+func (s *textShape) addLine(line string, tokens []token) {
+	runes := []rune(strings.TrimRight(line, "\r\n"))
+
+	if strings.TrimSpace(string(runes)) == "" {
+		s.endParagraph()
+		return
+	}
+	if !s.inParagraph {
+		s.Paragraphs++
+		s.inParagraph = true
+	}
+
+	for _, r := range runes {
+		s.Chars++
+		if !unicode.IsSpace(r) {
+			s.CharsNoSpaces++
+		}
+	}
+
+	for _, t := range tokens {
+		if t.Kind != tokenWord {
+			continue
+		}
+		for _, r := range t.Text {
+			if unicode.IsLetter(r) {
+				s.Letters++
+			}
+		}
+		s.Syllables += syllables(t.Text)
+		if wordLang(t.Text) == "uk" {
+			s.CyrillicWords++
+		}
+	}
+
+	// Проходимо рядок, перестрибуючи токени: крапка в "3.14" — не кінець речення
+	ti := 0
+	for i := 0; i < len(runes); i++ {
+		if ti < len(tokens) && tokens[ti].Col-1 == i {
+			if tokens[ti].Kind == tokenWord {
+				s.inSentence = true
+			}
+			i += utf8.RuneCountInString(tokens[ti].Raw) - 1
+			ti++
+			continue
+		}
+		if !isSentenceEnd(runes[i]) || !s.inSentence {
+			continue
+		}
+		// Крапка перед малою літерою — скорочення ("т. зв. слово")
+		if runes[i] == '.' && nextIsLower(runes[i+1:]) {
+			continue
+		}
+		s.Sentences++
+		s.inSentence = false
+	}
+}
+
+func nextIsLower(rest []rune) bool {
+	for _, r := range rest {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// Ділить текст на речення за тими самими правилами, що й textShape, і
+// зберігає текст кожного речення. Споживач отримує події через функції:
+// токени речення, межі його частин (кома, двокрапка, тире, дужки) та кінець.
+type sentenceSplitter struct {
+	onToken  func(t token)
+	onClause func()
+	onEnd    func(line int, text string)
+
+	text       strings.Builder
+	line       int
+	inSentence bool
+}
+
+func (s *sentenceSplitter) addLine(lineNo int, line string, tokens []token) {
+	runes := []rune(strings.TrimRight(line, "\r\n"))
+	if strings.TrimSpace(string(runes)) == "" {
+		s.end()
+		return
+	}
+
+	start, ti := 0, 0
+	for i := 0; i < len(runes); i++ {
+		if ti < len(tokens) && tokens[ti].Col-1 == i {
+			if tokens[ti].Kind == tokenWord && !s.inSentence {
+				s.inSentence = true
+				s.line = lineNo
+			}
+			if s.inSentence && s.onToken != nil {
+				s.onToken(tokens[ti])
+			}
+			i += utf8.RuneCountInString(tokens[ti].Raw) - 1
+			ti++
+			continue
+		}
+		r := runes[i]
+		if !s.inSentence {
+			start = i + 1 // розділові знаки та пробіли між реченнями
+			continue
+		}
+		switch {
+		case isSentenceEnd(r) && !(r == '.' && nextIsLower(runes[i+1:])):
+			for i+1 < len(runes) && isSentenceEnd(runes[i+1]) {
+				i++ // "...", "?!" — один кінець речення
+			}
+			s.text.WriteString(string(runes[start : i+1]))
+			s.end()
+			start = i + 1
+		case strings.ContainsRune(",;:—–()", r) && s.onClause != nil:
+			s.onClause()
+		}
+	}
+	if s.inSentence && start < len(runes) {
+		s.text.WriteString(string(runes[start:]))
+		s.text.WriteByte(' ')
+	}
+}
+
+// Закриває поточне речення (кінцевий знак, порожній рядок або кінець тексту)
+func (s *sentenceSplitter) end() {
+	if s.inSentence && s.onEnd != nil {
+		s.onEnd(s.line, strings.Join(strings.Fields(s.text.String()), " "))
+	}
+	s.text.Reset()
+	s.inSentence = false
+}
+
+// Порожній рядок або кінець тексту закривають абзац та незавершене речення
+func (s *textShape) endParagraph() {
+	if s.inSentence {
+		s.Sentences++
+		s.inSentence = false
+	}
+	s.inParagraph = false
+}
+
+func (s *textShape) merge(other textShape) {
+	s.Sentences += other.Sentences
+	s.Paragraphs += other.Paragraphs
+	s.Chars += other.Chars
+	s.CharsNoSpaces += other.CharsNoSpaces
+	s.Letters += other.Letters
+	s.Syllables += other.Syllables
+	s.CyrillicWords += other.CyrillicWords
+}
+
+// Кількість складів у слові: для кирилиці — кількість голосних,
+// для латиниці — групи голосних з поправкою на німе e
+func syllables(word string) int {
+	if wordLang(word) == "uk" {
+		n := 0
+		for _, r := range word {
+			if isVowelUK(r) {
+				n++
+			}
+		}
+		return max(n, 1)
+	}
+
+	n := 0
+	prevVowel := false
+	for _, r := range word {
+		v := strings.ContainsRune("aeiouy", r)
+		if v && !prevVowel {
+			n++
+		}
+		prevVowel = v
+	}
+	// Німе e в кінці ("make"), але не "-le" ("table") і не "-ee" ("free")
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && !strings.HasSuffix(word, "ee") && n > 1 {
+		n--
+	}
+	return max(n, 1)
+}
+
+// Індекс читабельності та назва формули для переважної мови тексту
+func (s textShape) readability(words int) (float64, string) {
+	if words == 0 || s.Sentences == 0 {
+		return 0, ""
+	}
+	asl := float64(words) / float64(s.Sentences)
+	asw := float64(s.Syllables) / float64(words)
+	if s.CyrillicWords*2 > words {
+		return 206.835 - 1.3*asl - 60.1*asw, "адаптований Флеш (українська)"
+	}
+	return 206.835 - 1.015*asl - 84.6*asw, "Флеш (англійська)"
+}
+
+func readabilityLevel(score float64) string {
+	switch {
+	case score >= 90:
+		return "дуже легко"
+	case score >= 70:
+		return "легко"
+	case score >= 60:
+		return "помірно"
+	case score >= 50:
+		return "досить складно"
+	case score >= 30:
+		return "складно"
+	}
+	return "дуже складно"
+}
+
+// Виводить зведену панель метрик тексту
+func printReadability(stats textStats) {
+	shape := stats.Shape
+	words := stats.Words
+
+	fmt.Println("+------------------------------------------+")
+	fmt.Printf("| %s |\n", padRight("Структура та читабельність", 40))
+	fmt.Println("+------------------------------------------+")
+	row := func(label string, value string) {
+		fmt.Printf("| %s %s |\n", padRight(label, 28), padLeft(value, 11))
+	}
+	row("Речень", fmt.Sprint(shape.Sentences))
+	row("Абзаців", fmt.Sprint(shape.Paragraphs))
+	row("Символів з пробілами", fmt.Sprint(shape.Chars))
+	row("Символів без пробілів", fmt.Sprint(shape.CharsNoSpaces))
+	row("Складів", fmt.Sprint(shape.Syllables))
+	if shape.Sentences > 0 {
+		row("Сер. довжина речення, слів", fmt.Sprintf("%.1f", float64(words)/float64(shape.Sentences)))
+	}
+	row("Сер. довжина слова, літер", fmt.Sprintf("%.1f", float64(shape.Letters)/float64(words)))
+	row("Сер. складів у слові", fmt.Sprintf("%.2f", float64(shape.Syllables)/float64(words)))
+	row("Лексична різноманітність", fmt.Sprintf("%.3f", float64(len(stats.Freq))/float64(words)))
+	if score, formula := shape.readability(words); formula != "" {
+		fmt.Printf("| %s |\n", padRight("Індекс: "+formula, 40))
+		row("  "+readabilityLevel(score), fmt.Sprintf("%.1f", score))
+	}
+	fmt.Println("+------------------------------------------+")
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ---------- Пошук слів та конкорданс ----------
+// Режими пошуку:
+// - exact     — слово збігається повністю;
+// - prefix    — слово починається з шаблону (узагальнення пошуку за літерою);
+// - substring — шаблон міститься у слові;
+// - regex     — регулярний вираз (без урахування регістру);
+// - fuzzy     — відстань Левенштейна до шаблону не більша за задану.
+// Кожен збіг виводиться з позицією та контекстом (KWIC — keyword in context).
+
+var searchModes = []string{"exact", "prefix", "substring", "regex", "fuzzy"}
+
+// Параметри пошуку
+type searchOptions struct {
+	Pattern  string
+	Mode     string
+	Distance int // максимальна відстань для fuzzy
+}
+
+// Перевіряє, чи нормалізоване слово відповідає шаблону
+type matcher func(word string) bool
+
+func newMatcher(opts searchOptions) (matcher, error) {
+	pattern := normalizeWord(opts.Pattern)
+
+	switch opts.Mode {
+	case "exact", "":
+		return func(w string) bool { return w == pattern }, nil
+	case "prefix":
+		return func(w string) bool { return strings.HasPrefix(w, pattern) }, nil
+	case "substring":
+		return func(w string) bool { return strings.Contains(w, pattern) }, nil
+	case "regex":
+		re, err := regexp.Compile("(?i)" + opts.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("некоректний регулярний вираз: %w", err)
+		}
+		return re.MatchString, nil
+	case "fuzzy":
+		if opts.Distance < 0 {
+			return nil, fmt.Errorf("відстань не може бути від'ємною: %d", opts.Distance)
+		}
+		p := []rune(pattern)
+		return func(w string) bool { return levenshtein(p, []rune(w), opts.Distance) <= opts.Distance }, nil
+	}
+	return nil, fmt.Errorf("невідомий режим пошуку: %s (%s)", opts.Mode, strings.Join(searchModes, ", "))
+}
+
+// Відстань Левенштейна між a та b. Якщо вона напевно більша за limit,
+// повертає limit+1, не дораховуючи таблицю.
+func levenshtein(a, b []rune, limit int) int {
+	if d := len(a) - len(b); d > limit || -d > limit {
+		return limit + 1
+	}
+
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Один знайдений збіг
+type searchMatch struct {
+	Line  int
+	Col   int
+	Word  string // як у тексті
+	Left  string // контекст зліва
+	Right string // контекст справа
+}
+
+// Збирає збіги з потоку токенів. Контекст справа дописується, коли
+// надходять наступні токени, тож текст не треба тримати в пам'яті.
+// Контекст не виходить за межі абзацу.
+type concordance struct {
+	match   matcher
+	context int
+	window  []string // останні слова — контекст зліва для наступного збігу
+	pending []pendingMatch
+	Matches []searchMatch
+}
+
+// Збіг, якому ще бракує слів контексту справа
+type pendingMatch struct {
+	index int // індекс у Matches
+	need  int // скільки слів ще дописати
+}
+
+func newConcordance(match matcher, context int) *concordance {
+	return &concordance{match: match, context: max(context, 0)}
+}
+
+func (c *concordance) add(t token) {
+	if t.Kind != tokenWord {
+		return
+	}
+
+	// Дописуємо слово до контексту справа попередніх збігів
+	kept := c.pending[:0]
+	for _, p := range c.pending {
+		m := &c.Matches[p.index]
+		if m.Right != "" {
+			m.Right += " "
+		}
+		m.Right += t.Raw
+		if p.need--; p.need > 0 {
+			kept = append(kept, p)
+		}
+	}
+	c.pending = kept
+
+	if c.match(t.Text) {
+		c.Matches = append(c.Matches, searchMatch{
+			Line: t.Line,
+			Col:  t.Col,
+			Word: t.Raw,
+			Left: strings.Join(c.window, " "),
+		})
+		if c.context > 0 {
+			c.pending = append(c.pending, pendingMatch{index: len(c.Matches) - 1, need: c.context})
+		}
+	}
+
+	if c.context > 0 {
+		if len(c.window) == c.context {
+			c.window = c.window[1:]
+		}
+		c.window = append(c.window, t.Raw)
+	}
+}
+
+// Порожній рядок завершує абзац: контекст не переходить через його межу
+func (c *concordance) endParagraph() {
+	c.window = c.window[:0]
+	c.pending = c.pending[:0]
+}
+
+// Виводить збіги у вигляді конкордансу: контекст зліва вирівняний праворуч,
+// тож знайдені слова стоять одне під одним
+func writeConcordance(w io.Writer, name string, matches []searchMatch) {
+	if len(matches) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n--- Конкорданс: %s ---\n", name)
+
+	posWidth, leftWidth := 0, 0
+	for _, m := range matches {
+		posWidth = max(posWidth, len(fmt.Sprintf("%d:%d", m.Line, m.Col)))
+		leftWidth = max(leftWidth, utf8.RuneCountInString(m.Left))
+	}
+	for _, m := range matches {
+		pos := fmt.Sprintf("%d:%d", m.Line, m.Col)
+		line := fmt.Sprintf("%s  %s [%s] %s", padRight(pos, posWidth), padLeft(m.Left, leftWidth), m.Word, m.Right)
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+}
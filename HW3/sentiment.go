@@ -0,0 +1,282 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------- Тональність тексту ----------
+// Оцінка за словником тональності (data/sentiment_*.txt): кожне оцінне
+// слово має вагу від -3 до 3. У реченні ваги слів додаються з урахуванням
+// модифікаторів, що стоять перед словом (не далі ніж за sentimentScope слів
+// і в межах частини речення до коми):
+// - підсилювач множить вагу ("дуже добрий" — 2*1.5);
+// - заперечення змінює знак і послаблює вагу ("не погано" — -2*-0.75),
+//   бо "не погано" — це ще не "добре".
+// Сума речення зводиться до шкали -1..1: x / sqrt(x² + 15) (як у VADER),
+// оцінка документа — середнє оцінок речень. Речення вважається позитивним
+// при оцінці понад 0.05 і негативним — нижче -0.05.
+
+//go:embed data/sentiment_uk.txt
+var bundledSentimentUK string
+
+//go:embed data/sentiment_en.txt
+var bundledSentimentEN string
+
+const (
+	sentimentScope    = 3     // скільки слів діє модифікатор
+	sentimentNegation = -0.75 // множник для заперечення
+	sentimentAlpha    = 15    // параметр нормалізації суми
+	sentimentNeutral  = 0.05  // межа нейтральної оцінки
+	sentimentMinStem  = 4     // коротші основи порівнюються лише точно
+)
+
+type sentimentLexicon struct {
+	scores   map[string]float64
+	stems    map[string]float64
+	boosters map[string]float64
+	negators map[string]bool
+}
+
+var (
+	sentimentOnce sync.Once
+	sentimentLex  *sentimentLexicon
+)
+
+func loadSentimentLexicon() *sentimentLexicon {
+	sentimentOnce.Do(func() {
+		lex := &sentimentLexicon{
+			scores:   make(map[string]float64),
+			stems:    make(map[string]float64),
+			boosters: make(map[string]float64),
+			negators: make(map[string]bool),
+		}
+		for _, data := range []string{bundledSentimentUK, bundledSentimentEN} {
+			for _, line := range strings.Split(data, "\n") {
+				fields := strings.Fields(line)
+				if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+					continue
+				}
+				word := normalizeWord(fields[0])
+				switch {
+				case fields[1] == "!":
+					lex.negators[word] = true
+				case strings.HasPrefix(fields[1], "*"):
+					if k, err := strconv.ParseFloat(fields[1][1:], 64); err == nil {
+						lex.boosters[word] = k
+					}
+				default:
+					score, err := strconv.ParseFloat(fields[1], 64)
+					if err != nil {
+						continue
+					}
+					lex.scores[word] = score
+					// Перше слово з такою основою має перевагу
+					if stem := stemWord(word, ""); utf8.RuneCountInString(stem) >= sentimentMinStem {
+						if _, ok := lex.stems[stem]; !ok {
+							lex.stems[stem] = score
+						}
+					}
+				}
+			}
+		}
+		sentimentLex = lex
+	})
+	return sentimentLex
+}
+
+// Вага слова: спершу точний збіг, потім за основою
+func (lex *sentimentLexicon) score(word string) (float64, bool) {
+	if s, ok := lex.scores[word]; ok {
+		return s, true
+	}
+	stem := stemWord(word, "")
+	if utf8.RuneCountInString(stem) < sentimentMinStem {
+		return 0, false
+	}
+	s, ok := lex.stems[stem]
+	return s, ok
+}
+
+func (lex *sentimentLexicon) isNegator(word string) bool {
+	return lex.negators[word] || strings.HasSuffix(word, "n't")
+}
+
+// Оцінка одного речення
+type sentenceSentiment struct {
+	Line  int
+	Text  string
+	Score float64 // сума ваг слів
+}
+
+// Внесок слова в тональність документа
+type sentimentWord struct {
+	Score float64
+	Count int
+}
+
+// Збирає речення та оцінні слова
+type sentimentCollector struct {
+	lex       *sentimentLexicon
+	split     sentenceSplitter
+	Sentences []sentenceSentiment
+	Words     map[string]sentimentWord
+	sum       float64 // сума ваг поточного речення
+
+	// Модифікатори, що чекають на оцінне слово
+	negate bool
+	boost  float64
+	scope  int
+}
+
+func newSentimentCollector() *sentimentCollector {
+	c := &sentimentCollector{lex: loadSentimentLexicon(), Words: make(map[string]sentimentWord), boost: 1}
+	c.split.onToken = func(t token) {
+		if t.Kind == tokenWord {
+			c.addWord(t.Text)
+		}
+	}
+	c.split.onClause = c.resetModifiers
+	c.split.onEnd = func(line int, text string) {
+		c.Sentences = append(c.Sentences, sentenceSentiment{Line: line, Text: text, Score: c.sum})
+		c.sum = 0
+		c.resetModifiers()
+	}
+	return c
+}
+
+func (c *sentimentCollector) addLine(lineNo int, line string, tokens []token) {
+	c.split.addLine(lineNo, line, tokens)
+}
+
+// Закриває останнє речення
+func (c *sentimentCollector) endSentence() {
+	c.split.end()
+}
+
+func (c *sentimentCollector) addWord(word string) {
+	if c.lex.isNegator(word) {
+		c.negate = true
+		c.scope = sentimentScope
+		return
+	}
+	if k, ok := c.lex.boosters[word]; ok {
+		c.boost *= k
+		c.scope = sentimentScope
+		return
+	}
+	if s, ok := c.lex.score(word); ok {
+		s *= c.boost
+		if c.negate {
+			s *= sentimentNegation
+		}
+		c.sum += s
+		w := c.Words[word]
+		w.Score += s
+		w.Count++
+		c.Words[word] = w
+		c.resetModifiers()
+		return
+	}
+	if c.scope > 0 {
+		c.scope--
+		if c.scope == 0 {
+			c.resetModifiers()
+		}
+	}
+}
+
+func (c *sentimentCollector) resetModifiers() {
+	c.negate, c.boost, c.scope = false, 1, 0
+}
+
+// Зводить суму ваг до шкали -1..1
+func sentimentCompound(sum float64) float64 {
+	return sum / math.Sqrt(sum*sum+sentimentAlpha)
+}
+
+func sentimentLabel(score float64) string {
+	switch {
+	case score > sentimentNeutral:
+		return "позитивна"
+	case score < -sentimentNeutral:
+		return "негативна"
+	}
+	return "нейтральна"
+}
+
+// Виводить тональність документа, речень та найвагоміші слова
+func writeSentiment(w io.Writer, name string, sentences []sentenceSentiment, words map[string]sentimentWord, showSentences bool) {
+	fmt.Fprintf(w, "\n--- Тональність: %s ---\n", name)
+	if len(sentences) == 0 {
+		fmt.Fprintln(w, "Речень не знайдено.")
+		return
+	}
+
+	var mean, sum float64
+	var pos, neg int
+	for _, s := range sentences {
+		c := sentimentCompound(s.Score)
+		mean += c
+		sum += s.Score
+		switch {
+		case c > sentimentNeutral:
+			pos++
+		case c < -sentimentNeutral:
+			neg++
+		}
+	}
+	mean /= float64(len(sentences))
+	fmt.Fprintf(w, "Документ: %s (%+.2f), сума ваг слів %+.2f\n", sentimentLabel(mean), mean, sum)
+	fmt.Fprintf(w, "Речень: %d — позитивних %d, негативних %d, нейтральних %d\n",
+		len(sentences), pos, neg, len(sentences)-pos-neg)
+
+	if showSentences {
+		fmt.Fprintln(w, "\nРечення:")
+		for _, s := range sentences {
+			fmt.Fprintf(w, "  рядок %-4d %+.2f  %s\n", s.Line, sentimentCompound(s.Score), shorten(s.Text, 80))
+		}
+	}
+
+	if len(words) == 0 {
+		return
+	}
+	list := make([]string, 0, len(words))
+	for word := range words {
+		list = append(list, word)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := math.Abs(words[list[i]].Score), math.Abs(words[list[j]].Score)
+		if a != b {
+			return a > b
+		}
+		return list[i] < list[j]
+	})
+	fmt.Fprintln(w, "\nНайбільший внесок:")
+	for _, sign := range []float64{1, -1} {
+		shown := 0
+		for _, word := range list {
+			if s := words[word]; s.Score*sign > 0 && shown < 5 {
+				fmt.Fprintf(w, "  %-20s %+6.2f (%d×)\n", word, s.Score, s.Count)
+				shown++
+			}
+		}
+	}
+}
+
+// Обрізає текст до n символів
+func shorten(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimRightFunc(string(runes[:n-1]), unicode.IsSpace) + "…"
+}
@@ -0,0 +1,762 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------- Перевірка орфографії ----------
+// Словники задаються файлами:
+// - Hunspell: <назва>.dic разом з <назва>.aff поруч (наприклад, uk_UA.dic
+//   та en_US.dic зі словників LibreOffice). Підтримується підмножина .aff:
+//   SET (UTF-8, ISO8859-1), FLAG (за замовчуванням, long, num, UTF-8),
+//   AF (псевдоніми прапорців), PFX/SFX з умовами та перехресним поєднанням,
+//   двоступеневі суфікси (прапорці продовження), NEEDAFFIX, FORBIDDENWORD;
+// - простий список: одне слово в рядку, за бажанням з частотою через
+//   пробіл ("кіт 1520") — частота впливає на порядок підказок.
+// Слово вважається відомим, якщо його приймає хоча б один словник; правила
+// PFX/SFX кожного .aff застосовуються лише до слів його власного .dic.
+//
+// Підказки — відомі слова (з усіма формами) на відстані редагування 1
+// (вставка, видалення, заміна, перестановка сусідніх літер). Якщо таких
+// немає, шукаються слова словника (без афіксів) на відстані 2 — обходом
+// префіксного дерева з обчисленням відстані по рядках.
+// Вони впорядковуються за відстанню, потім за частотою слова в документі,
+// потім за частотою у словнику.
+
+const spellSuggestions = 5 // скільки підказок показувати
+
+var (
+	alphabetUK = []rune("абвгґдеєжзиіїйклмнопрстуфхцчшщьюя'")
+	alphabetEN = []rune("abcdefghijklmnopqrstuvwxyz'")
+)
+
+// Правило префікса або суфікса
+type affixRule struct {
+	flag  string
+	cross bool      // можна поєднувати з правилом іншого типу
+	strip string    // що відрізати від основи
+	add   string    // що додати
+	cont  []string  // прапорці продовження (після "/")
+	cond  []charSet // умова на основу (кінець для суфікса, початок для префікса)
+}
+
+// Один символ умови: "." — будь-який, "[аб]" — один з, "[^аб]" — жоден з
+type charSet struct {
+	any    bool
+	negate bool
+	runes  string
+}
+
+func (c charSet) match(r rune) bool {
+	if c.any {
+		return true
+	}
+	return strings.ContainsRune(c.runes, r) != c.negate
+}
+
+func parseCondition(cond string) []charSet {
+	if cond == "." || cond == "" {
+		return nil
+	}
+	var sets []charSet
+	runes := []rune(cond)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '.':
+			sets = append(sets, charSet{any: true})
+		case '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			set := charSet{runes: string(runes[i+1 : min(end, len(runes))])}
+			if strings.HasPrefix(set.runes, "^") {
+				set.negate = true
+				set.runes = set.runes[1:]
+			}
+			sets = append(sets, set)
+			i = end
+		default:
+			sets = append(sets, charSet{runes: string(runes[i])})
+		}
+	}
+	return sets
+}
+
+// Перевіряє умову суфікса на кінці основи
+func (r *affixRule) matchSuffix(base string) bool {
+	runes := []rune(base)
+	if len(runes) < len(r.cond) {
+		return false
+	}
+	runes = runes[len(runes)-len(r.cond):]
+	for i, c := range r.cond {
+		if !c.match(runes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Перевіряє умову префікса на початку основи
+func (r *affixRule) matchPrefix(base string) bool {
+	runes := []rune(base)
+	if len(runes) < len(r.cond) {
+		return false
+	}
+	for i, c := range r.cond {
+		if !c.match(runes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Слово словника (омоніми з різними прапорцями зливаються)
+type dictEntry struct {
+	flags      []string
+	standalone bool // слово можна вживати без афіксів
+}
+
+func (e *dictEntry) has(flag string) bool {
+	for _, f := range e.flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Об'єднаний словник з усіх файлів
+type spellDict struct {
+	roots    map[string]*dictEntry
+	freq     map[string]int
+	prefixes map[string][]*affixRule // за доданою частиною
+	suffixes map[string][]*affixRule
+	// Найдовші додані частини в байтах: довші закінчення не перевіряємо
+	maxPrefix, maxSuffix int
+	// Скільки файлів .aff завантажено. Прапорці кожного словника Hunspell
+	// мають префікс "<номер>:", щоб правило одного словника не застосовувалось
+	// до слів іншого, де та сама літера-прапорець означає інше.
+	affixSets int
+
+	trieOnce sync.Once
+	trie     *trieNode // слова словника для пошуку на відстані 2
+}
+
+func newSpellDict() *spellDict {
+	return &spellDict{
+		roots:    make(map[string]*dictEntry),
+		freq:     make(map[string]int),
+		prefixes: make(map[string][]*affixRule),
+		suffixes: make(map[string][]*affixRule),
+	}
+}
+
+// Завантажує словники через кому та словник користувача
+func loadSpellDict(files, userFile string) (*spellDict, error) {
+	d := newSpellDict()
+	for _, path := range strings.Split(files, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		aff := strings.TrimSuffix(path, filepath.Ext(path)) + ".aff"
+		var err error
+		if _, statErr := os.Stat(aff); strings.EqualFold(filepath.Ext(path), ".dic") && statErr == nil {
+			err = d.loadHunspell(path, aff)
+		} else {
+			err = d.loadWordList(path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if len(d.roots) == 0 {
+		return nil, fmt.Errorf("словник порожній")
+	}
+	if userFile != "" {
+		if err := d.loadWordList(userFile); err != nil {
+			return nil, fmt.Errorf("%s: %w", userFile, err)
+		}
+	}
+	return d, nil
+}
+
+func (d *spellDict) addWord(word string, flags []string, standalone bool) {
+	e := d.roots[word]
+	if e == nil {
+		e = &dictEntry{}
+		d.roots[word] = e
+	}
+	e.flags = append(e.flags, flags...)
+	e.standalone = e.standalone || standalone
+}
+
+// Простий список слів: "слово" або "слово частота"; # — коментар
+func (d *spellDict) loadWordList(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		word := normalizeWord(fields[0])
+		d.addWord(word, nil, true)
+		if len(fields) > 1 {
+			if n, err := strconv.Atoi(fields[1]); err == nil {
+				d.freq[word] += n
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// Налаштування з файлу .aff, потрібні для читання .dic
+type affixFile struct {
+	encoding  string
+	flagMode  string
+	aliases   [][]string
+	needAffix string
+	forbidden string
+}
+
+// Розбирає рядок прапорців відповідно до FLAG та AF
+func (a *affixFile) parseFlags(s string) []string {
+	if len(a.aliases) > 0 {
+		if n, err := strconv.Atoi(s); err == nil {
+			if n >= 1 && n <= len(a.aliases) {
+				return a.aliases[n-1]
+			}
+			return nil
+		}
+	}
+	var flags []string
+	switch a.flagMode {
+	case "long":
+		runes := []rune(s)
+		for i := 0; i+1 < len(runes); i += 2 {
+			flags = append(flags, string(runes[i:i+2]))
+		}
+	case "num":
+		for _, f := range strings.Split(s, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				flags = append(flags, f)
+			}
+		}
+	default:
+		for _, r := range s {
+			flags = append(flags, string(r))
+		}
+	}
+	return flags
+}
+
+// Перекодовує рядок у UTF-8
+func (a *affixFile) decode(line string) string {
+	if a.encoding != "ISO8859-1" {
+		return line
+	}
+	runes := make([]rune, len(line))
+	for i := 0; i < len(line); i++ {
+		runes[i] = rune(line[i])
+	}
+	return string(runes)
+}
+
+// Додає до прапорців простір імен словника (новий зріз: псевдоніми AF спільні)
+func namespaceFlags(ns string, flags []string) []string {
+	if len(flags) == 0 {
+		return nil
+	}
+	out := make([]string, len(flags))
+	for i, f := range flags {
+		out[i] = ns + f
+	}
+	return out
+}
+
+func (d *spellDict) loadHunspell(dicPath, affPath string) error {
+	ns := strconv.Itoa(d.affixSets) + ":"
+	d.affixSets++
+	aff, err := d.loadAffixes(affPath, ns)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(dicPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(aff.decode(scanner.Text()))
+		if first {
+			// Перший рядок — кількість слів
+			first = false
+			if _, err := strconv.Atoi(line); err == nil {
+				continue
+			}
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Морфологічні поля після пробілу чи табуляції відкидаємо
+		if i := strings.IndexAny(line, " \t"); i != -1 {
+			line = line[:i]
+		}
+		word, flagStr := line, ""
+		if i := strings.Index(line, "/"); i > 0 {
+			word, flagStr = line[:i], line[i+1:]
+		}
+		flags := aff.parseFlags(flagStr)
+		standalone := true
+		for _, f := range flags {
+			if f == aff.needAffix || f == aff.forbidden {
+				standalone = false
+			}
+		}
+		d.addWord(normalizeWord(word), namespaceFlags(ns, flags), standalone)
+	}
+	return scanner.Err()
+}
+
+// Читає .aff. Прапорці правил отримують простір імен ns свого словника.
+func (d *spellDict) loadAffixes(path, ns string) (*affixFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	aff := &affixFile{}
+	remaining := make(map[string]int) // скільки правил ще чекає кожен PFX/SFX
+	cross := make(map[string]bool)
+	aliasHeader := false
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(aff.decode(scanner.Text()))
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch fields[0] {
+		case "SET":
+			aff.encoding = strings.ToUpper(fields[1])
+			if aff.encoding != "UTF-8" && aff.encoding != "ISO8859-1" {
+				return nil, fmt.Errorf("кодування %s не підтримується, перекодуйте словник у UTF-8", fields[1])
+			}
+		case "FLAG":
+			aff.flagMode = fields[1]
+		case "AF":
+			if !aliasHeader {
+				aliasHeader = true // перший рядок AF — кількість псевдонімів
+				continue
+			}
+			aff.aliases = append(aff.aliases, aff.parseFlags(fields[1]))
+		case "NEEDAFFIX":
+			aff.needAffix = fields[1]
+		case "FORBIDDENWORD":
+			aff.forbidden = fields[1]
+		case "PFX", "SFX":
+			key := fields[0] + " " + fields[1]
+			if remaining[key] == 0 {
+				// Заголовок: SFX A Y 3
+				if len(fields) < 4 {
+					continue
+				}
+				cross[key] = fields[2] == "Y"
+				remaining[key], _ = strconv.Atoi(fields[3])
+				continue
+			}
+			remaining[key]--
+			if len(fields) < 4 {
+				continue
+			}
+			rule := &affixRule{flag: ns + fields[1], cross: cross[key], strip: fields[2], add: fields[3]}
+			if rule.strip == "0" {
+				rule.strip = ""
+			}
+			if i := strings.Index(rule.add, "/"); i != -1 {
+				rule.cont = namespaceFlags(ns, aff.parseFlags(rule.add[i+1:]))
+				rule.add = rule.add[:i]
+			}
+			if rule.add == "0" {
+				rule.add = ""
+			}
+			rule.strip, rule.add = normalizeWord(rule.strip), normalizeWord(rule.add)
+			if len(fields) > 4 {
+				rule.cond = parseCondition(normalizeWord(fields[4]))
+			}
+			if fields[0] == "PFX" {
+				d.prefixes[rule.add] = append(d.prefixes[rule.add], rule)
+				d.maxPrefix = max(d.maxPrefix, len(rule.add))
+			} else {
+				d.suffixes[rule.add] = append(d.suffixes[rule.add], rule)
+				d.maxSuffix = max(d.maxSuffix, len(rule.add))
+			}
+		}
+	}
+	return aff, scanner.Err()
+}
+
+// Чи є слово у словнику (з урахуванням афіксів)
+func (d *spellDict) known(word string) bool {
+	if e := d.roots[word]; e != nil && e.standalone {
+		return true
+	}
+	if d.suffixed(word, "", false) {
+		return true
+	}
+	// Префікс (і, якщо дозволено, ще й суфікс)
+	if len(d.prefixes) == 0 {
+		return false
+	}
+	for i := 0; i <= min(len(word), d.maxPrefix); i++ {
+		if i < len(word) && !utf8.RuneStart(word[i]) {
+			continue
+		}
+		for _, r := range d.prefixes[word[:i]] {
+			base := r.strip + word[i:]
+			if base == "" || !r.matchPrefix(base) {
+				continue
+			}
+			if e := d.roots[base]; e != nil && e.has(r.flag) {
+				return true
+			}
+			if r.cross && d.suffixed(base, r.flag, false) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Чи утворене слово від основи суфіксом. prefixFlag — прапорець префікса,
+// який уже відрізали (основа або суфікс мають його дозволяти).
+// nested — перевіряємо внутрішній суфікс двоступеневої форми.
+func (d *spellDict) suffixed(word, prefixFlag string, nested bool) bool {
+	if len(d.suffixes) == 0 {
+		return false
+	}
+	for i := len(word); i >= max(len(word)-d.maxSuffix, 0); i-- {
+		if i < len(word) && !utf8.RuneStart(word[i]) {
+			continue
+		}
+		for _, r := range d.suffixes[word[i:]] {
+			base := word[:i] + r.strip
+			if base == "" || !r.matchSuffix(base) {
+				continue
+			}
+			if prefixFlag != "" && !r.cross {
+				continue
+			}
+			if e := d.roots[base]; e != nil && e.has(r.flag) {
+				if prefixFlag == "" || e.has(prefixFlag) || contains(r.cont, prefixFlag) {
+					return true
+				}
+			}
+			// Двоступеневий суфікс: основа сама утворена суфіксом,
+			// що дозволяє продовження r.flag
+			if !nested && prefixFlag == "" && d.suffixedWith(base, r.flag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Чи утворене слово суфіксом, у продовженні якого є flag
+func (d *spellDict) suffixedWith(word, flag string) bool {
+	if len(d.suffixes) == 0 {
+		return false
+	}
+	for i := len(word); i >= max(len(word)-d.maxSuffix, 0); i-- {
+		if i < len(word) && !utf8.RuneStart(word[i]) {
+			continue
+		}
+		for _, r := range d.suffixes[word[i:]] {
+			if !contains(r.cont, flag) {
+				continue
+			}
+			base := word[:i] + r.strip
+			if base == "" || !r.matchSuffix(base) {
+				continue
+			}
+			if e := d.roots[base]; e != nil && e.has(r.flag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Чи правильне слово тексту. raw — слово як у тексті.
+func (d *spellDict) check(word, raw string) bool {
+	// Числа з літерами (mp3) та абревіатури (НАТО, USA) не перевіряємо
+	if strings.IndexFunc(word, unicode.IsDigit) != -1 {
+		return true
+	}
+	if utf8.RuneCountInString(raw) > 1 && strings.ToUpper(raw) == raw {
+		return true
+	}
+	if d.known(word) {
+		return true
+	}
+	// Складне слово через дефіс правильне, якщо правильні всі частини
+	if strings.Contains(word, "-") {
+		for _, part := range strings.Split(word, "-") {
+			if part != "" && !d.known(part) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Варіанти виправлення
+type spellCandidate struct {
+	Word string
+	Dist int
+}
+
+// Підказки для невідомого слова: спершу відстань 1, інакше 2.
+// docFreq — частоти слів документа для впорядкування.
+func (d *spellDict) suggest(word string, docFreq map[string]int) []spellCandidate {
+	// У складному слові виправляємо першу невідому частину
+	if parts := strings.Split(word, "-"); len(parts) > 1 {
+		for i, part := range parts {
+			if part == "" || d.known(part) {
+				continue
+			}
+			list := d.suggest(part, docFreq)
+			for j := range list {
+				parts[i] = list[j].Word
+				list[j].Word = strings.Join(parts, "-")
+			}
+			return list
+		}
+	}
+
+	alphabet := alphabetEN
+	if wordLang(word) == "uk" {
+		alphabet = alphabetUK
+	}
+
+	found := make(map[string]int)
+	for _, e := range editsOne(word, alphabet) {
+		if d.known(e) {
+			found[e] = 1
+		}
+	}
+	if len(found) == 0 && utf8.RuneCountInString(word) > 2 {
+		d.trieOnce.Do(d.buildTrie)
+		d.trie.search([]rune(word), 2, found)
+	}
+
+	list := make([]spellCandidate, 0, len(found))
+	for w, dist := range found {
+		list = append(list, spellCandidate{Word: w, Dist: dist})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Dist != b.Dist {
+			return a.Dist < b.Dist
+		}
+		if docFreq[a.Word] != docFreq[b.Word] {
+			return docFreq[a.Word] > docFreq[b.Word]
+		}
+		if d.freq[a.Word] != d.freq[b.Word] {
+			return d.freq[a.Word] > d.freq[b.Word]
+		}
+		return a.Word < b.Word
+	})
+	if len(list) > spellSuggestions {
+		list = list[:spellSuggestions]
+	}
+	return list
+}
+
+// Усі рядки на відстані одного редагування
+func editsOne(word string, alphabet []rune) []string {
+	runes := []rune(word)
+	var edits []string
+	for i := 0; i <= len(runes); i++ {
+		left, right := string(runes[:i]), runes[i:]
+		if len(right) > 0 {
+			edits = append(edits, left+string(right[1:])) // видалення
+			for _, c := range alphabet {
+				if c != right[0] {
+					edits = append(edits, left+string(c)+string(right[1:])) // заміна
+				}
+			}
+		}
+		if len(right) > 1 {
+			edits = append(edits, left+string(right[1])+string(right[0])+string(right[2:])) // перестановка
+		}
+		for _, c := range alphabet {
+			edits = append(edits, left+string(c)+string(right)) // вставка
+		}
+	}
+	return edits
+}
+
+// Вузол префіксного дерева слів словника
+type trieNode struct {
+	r    rune
+	word string // непорожнє, якщо тут закінчується слово
+	kids []*trieNode
+}
+
+func (d *spellDict) buildTrie() {
+	d.trie = &trieNode{}
+	for w, e := range d.roots {
+		if !e.standalone {
+			continue
+		}
+		node := d.trie
+		for _, r := range w {
+			node = node.child(r)
+		}
+		node.word = w
+	}
+}
+
+func (n *trieNode) child(r rune) *trieNode {
+	for _, k := range n.kids {
+		if k.r == r {
+			return k
+		}
+	}
+	k := &trieNode{r: r}
+	n.kids = append(n.kids, k)
+	return k
+}
+
+// Додає до found слова на відстані не більше limit (з перестановками)
+func (n *trieNode) search(target []rune, limit int, found map[string]int) {
+	row := make([]int, len(target)+1)
+	for i := range row {
+		row[i] = i
+	}
+	for _, k := range n.kids {
+		k.walk(target, limit, 0, row, nil, found)
+	}
+}
+
+// Обчислює рядок таблиці відстаней для цього вузла і спускається глибше,
+// поки мінімум рядка не перевищує limit
+func (n *trieNode) walk(target []rune, limit int, prev rune, row, prevRow []int, found map[string]int) {
+	cur := make([]int, len(row))
+	cur[0] = row[0] + 1
+	best := cur[0]
+	for j := 1; j < len(cur); j++ {
+		cost := 1
+		if target[j-1] == n.r {
+			cost = 0
+		}
+		cur[j] = min(cur[j-1]+1, row[j]+1, row[j-1]+cost)
+		if prevRow != nil && j > 1 && target[j-1] == prev && target[j-2] == n.r {
+			cur[j] = min(cur[j], prevRow[j-2]+1)
+		}
+		best = min(best, cur[j])
+	}
+	if n.word != "" && cur[len(cur)-1] <= limit {
+		if d, ok := found[n.word]; !ok || cur[len(cur)-1] < d {
+			found[n.word] = cur[len(cur)-1]
+		}
+	}
+	if best > limit {
+		return
+	}
+	for _, k := range n.kids {
+		k.walk(target, limit, n.r, cur, row, found)
+	}
+}
+
+// Невідоме слово в тексті
+type misspelling struct {
+	Word string
+	Raw  string
+	Line int
+	Col  int
+}
+
+// Виводить невідомі слова з позиціями та підказками
+func writeMisspellings(w io.Writer, name string, list []misspelling, dict *spellDict, docFreq map[string]int) {
+	if dict == nil {
+		return
+	}
+	// Групуємо за словом у порядку першої появи
+	var order []string
+	positions := make(map[string][]misspelling)
+	for _, m := range list {
+		if _, ok := positions[m.Word]; !ok {
+			order = append(order, m.Word)
+		}
+		positions[m.Word] = append(positions[m.Word], m)
+	}
+
+	fmt.Fprintf(w, "\n--- Орфографія: %s ---\n", name)
+	if len(list) == 0 {
+		fmt.Fprintln(w, "Невідомих слів не знайдено.")
+		return
+	}
+	fmt.Fprintf(w, "Невідомих слів: %d (різних: %d)\n", len(list), len(order))
+
+	const maxPositions = 5
+	for _, word := range order {
+		occ := positions[word]
+		var pos []string
+		for i, m := range occ {
+			if i == maxPositions {
+				pos = append(pos, fmt.Sprintf("ще %d", len(occ)-maxPositions))
+				break
+			}
+			pos = append(pos, fmt.Sprintf("%d:%d", m.Line, m.Col))
+		}
+		fmt.Fprintf(w, "  %s (%s)", occ[0].Raw, strings.Join(pos, ", "))
+
+		suggestions := dict.suggest(word, docFreq)
+		if len(suggestions) == 0 {
+			fmt.Fprintln(w, " — варіантів немає")
+			continue
+		}
+		words := make([]string, len(suggestions))
+		for i, s := range suggestions {
+			words[i] = s.Word
+		}
+		fmt.Fprintf(w, " → %s\n", strings.Join(words, ", "))
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Два словники Hunspell з однаковим прапорцем A, що означає різні суфікси
+func TestSpellAffixesPerDictionary(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"uk.dic": "1\nкіт/A\n",
+		"uk.aff": "SET UTF-8\nSFX A Y 1\nSFX A 0 и .\n",
+		"en.dic": "1\ncat/A\n",
+		"en.aff": "SET UTF-8\nSFX A Y 1\nSFX A 0 s .\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := loadSpellDict(filepath.Join(dir, "uk.dic")+","+filepath.Join(dir, "en.dic"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for word, want := range map[string]bool{
+		"кіт": true, "кіти": true, "cat": true, "cats": true,
+		"кітs": false, "catи": false,
+	} {
+		if got := d.known(word); got != want {
+			t.Errorf("known(%q) = %v, очікувалось %v", word, got, want)
+		}
+	}
+}
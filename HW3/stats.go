@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ---------- Базова статистика тексту ----------
+
+// Параметри аналізу, спільні для всіх джерел
+type options struct {
+	Word    string     // слово для підрахунку входжень
+	Letter  string     // літера для пошуку першого слова
+	Stem    string     // слово для пошуку всіх форм за основою
+	StemKey string     // ключ основи Stem (див. stemKey)
+	Search  string     // шаблон пошуку (для виводу)
+	Match   matcher    // перевірка слова на збіг з шаблоном
+	Context int        // слів контексту з кожного боку у конкордансі
+	Ngrams  []int      // розміри n-грам для підрахунку (2, 3)
+	Lang    bool       // визначати мову документа
+	LangPar bool       // визначати мову кожного абзацу
+	Workers int        // скільки горутин аналізують текст (див. analyzeParallel)
+	Spell   *spellDict // словник для перевірки орфографії
+	Mood    bool       // оцінювати тональність речень і документа
+	Chars   bool       // рахувати статистику символів
+}
+
+// Результати аналізу одного джерела (або сумарні)
+type textStats struct {
+	Name            string
+	Lines           int
+	Words           int
+	LongestWord     string
+	WordCount       int                      // скільки разів зустрілося шукане слово
+	FirstWithLetter string                   // перше слово, що починається на задану літеру
+	Freq            map[string]int           // частоти слів (для словника та лексичної різноманітності)
+	StemForms       map[string]int           // знайдені форми слова Stem та їх кількість
+	Matches         []searchMatch            // збіги пошуку з позиціями та контекстом
+	Shape           textShape                // речення, абзаци, символи, склади
+	Ngrams          map[int]map[string]int   // частоти n-грам за розміром
+	Trigrams        map[string]int           // символьні триграми для визначення мови
+	ParagraphLangs  []paragraphLang          // мова кожного абзацу
+	Misspelled      []misspelling            // невідомі словнику слова з позиціями
+	SentenceMoods   []sentenceSentiment      // тональність кожного речення
+	MoodWords       map[string]sentimentWord // внесок оцінних слів
+	Chars           charStats                // частоти літер, письмо, регістр
+}
+
+// Аналізує текст порядково, не завантажуючи його в пам'ять цілком
+func analyze(name string, r io.Reader, opts options) (textStats, error) {
+	a := newLineAnalyzer(name, opts, 0, 0)
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			a.addLine(line)
+		}
+		if err == io.EOF {
+			return a.finish(), nil
+		}
+		if err != nil {
+			return a.finish(), err
+		}
+	}
+}
+
+// Порядковий аналіз фрагмента тексту. Фрагмент може бути частиною
+// більшого тексту: lineBase та offset — скільки рядків і байтів було до нього.
+type lineAnalyzer struct {
+	stats    textStats
+	opts     options
+	lineBase int
+	offset   int
+	conc     *concordance
+	ngrams   *ngramCounter
+	langs    *langCollector
+	mood     *sentimentCollector
+}
+
+func newLineAnalyzer(name string, opts options, firstLine, offset int) *lineAnalyzer {
+	a := &lineAnalyzer{
+		stats:    textStats{Name: name, Freq: make(map[string]int)},
+		opts:     opts,
+		lineBase: firstLine,
+		offset:   offset,
+	}
+	if opts.StemKey != "" {
+		a.stats.StemForms = make(map[string]int)
+	}
+	if opts.Match != nil {
+		a.conc = newConcordance(opts.Match, opts.Context)
+	}
+	if len(opts.Ngrams) > 0 {
+		a.ngrams = newNgramCounter(opts.Ngrams)
+		a.stats.Ngrams = a.ngrams.Counts
+	}
+	if opts.Lang || opts.LangPar {
+		a.langs = newLangCollector(opts.LangPar)
+		a.stats.Trigrams = a.langs.Counts
+	}
+	if opts.Chars {
+		a.stats.Chars = newCharStats()
+	}
+	if opts.Mood {
+		a.mood = newSentimentCollector()
+		a.stats.MoodWords = a.mood.Words
+	}
+	return a
+}
+
+// Враховує один рядок (разом із символом переносу, якщо він є)
+func (a *lineAnalyzer) addLine(line string) {
+	a.stats.Lines++
+	tokens := tokenizeLine(line, a.lineBase+a.stats.Lines, a.offset)
+	a.offset += len(line)
+
+	a.stats.Shape.addLine(line, tokens)
+	if a.ngrams != nil {
+		a.ngrams.addLine(line, tokens)
+	}
+	if a.langs != nil {
+		a.langs.addLine(a.lineBase+a.stats.Lines, line, tokens)
+	}
+	if a.opts.Chars {
+		a.stats.Chars.addLine(line, tokens)
+	}
+	if a.mood != nil {
+		a.mood.addLine(a.lineBase+a.stats.Lines, line, tokens)
+	}
+	if a.conc != nil && len(tokens) == 0 && strings.TrimSpace(line) == "" {
+		a.conc.endParagraph()
+	}
+
+	var words []string
+	for _, t := range tokens {
+		if t.Kind == tokenWord {
+			words = append(words, t.Text)
+			if a.opts.Spell != nil && !a.opts.Spell.check(t.Text, t.Raw) {
+				a.stats.Misspelled = append(a.stats.Misspelled, misspelling{Word: t.Text, Raw: t.Raw, Line: t.Line, Col: t.Col})
+			}
+		}
+		if a.conc != nil {
+			a.conc.add(t)
+		}
+	}
+	a.stats.addWords(words, a.opts)
+}
+
+// Завершує аналіз: закриває останній абзац і повертає статистику
+func (a *lineAnalyzer) finish() textStats {
+	a.stats.Shape.endParagraph()
+	if a.langs != nil {
+		a.langs.endParagraph()
+		a.stats.ParagraphLangs = a.langs.Paragraphs
+	}
+	if a.conc != nil {
+		a.stats.Matches = a.conc.Matches
+	}
+	if a.mood != nil {
+		a.mood.endSentence()
+		a.stats.SentenceMoods = a.mood.Sentences
+	}
+	return a.stats
+}
+
+// Враховує слова у статистиці
+func (s *textStats) addWords(words []string, opts options) {
+	for _, w := range words {
+		s.Words++
+		if s.Freq != nil {
+			s.Freq[w]++
+		}
+
+		// Рахуємо кількість входжень шуканого слова/літери
+		if opts.Word != "" && w == opts.Word {
+			s.WordCount++
+		}
+		// Форми шуканого слова: порівнюємо ключі основ
+		if opts.StemKey != "" && stemKey(w, "") == opts.StemKey {
+			s.StemForms[w]++
+		}
+		// Шукаємо найдовше слово (за кількістю символів)
+		if utf8.RuneCountInString(w) > utf8.RuneCountInString(s.LongestWord) {
+			s.LongestWord = w
+		}
+		// Перше слово, що починається на введену літеру
+		if opts.Letter != "" && s.FirstWithLetter == "" && strings.HasPrefix(w, opts.Letter) {
+			s.FirstWithLetter = w
+		}
+	}
+}
+
+// Додає статистику іншого джерела до сумарної
+func (s *textStats) merge(other textStats) {
+	s.Lines += other.Lines
+	s.Words += other.Words
+	s.WordCount += other.WordCount
+	if utf8.RuneCountInString(other.LongestWord) > utf8.RuneCountInString(s.LongestWord) {
+		s.LongestWord = other.LongestWord
+	}
+	if s.FirstWithLetter == "" {
+		s.FirstWithLetter = other.FirstWithLetter
+	}
+	if other.Freq != nil {
+		if s.Freq == nil {
+			s.Freq = make(map[string]int)
+		}
+		for w, n := range other.Freq {
+			s.Freq[w] += n
+		}
+	}
+	s.Matches = append(s.Matches, other.Matches...)
+	s.ParagraphLangs = append(s.ParagraphLangs, other.ParagraphLangs...)
+	s.Misspelled = append(s.Misspelled, other.Misspelled...)
+	s.SentenceMoods = append(s.SentenceMoods, other.SentenceMoods...)
+	if other.MoodWords != nil {
+		if s.MoodWords == nil {
+			s.MoodWords = make(map[string]sentimentWord)
+		}
+		for w, m := range other.MoodWords {
+			sum := s.MoodWords[w]
+			sum.Score += m.Score
+			sum.Count += m.Count
+			s.MoodWords[w] = sum
+		}
+	}
+	s.Shape.merge(other.Shape)
+	s.Chars.merge(other.Chars)
+	if other.Trigrams != nil {
+		if s.Trigrams == nil {
+			s.Trigrams = make(map[string]int)
+		}
+		for g, n := range other.Trigrams {
+			s.Trigrams[g] += n
+		}
+	}
+	for n, counts := range other.Ngrams {
+		if s.Ngrams == nil {
+			s.Ngrams = make(map[int]map[string]int)
+		}
+		if s.Ngrams[n] == nil {
+			s.Ngrams[n] = make(map[string]int)
+		}
+		for gram, count := range counts {
+			s.Ngrams[n][gram] += count
+		}
+	}
+	if other.StemForms != nil {
+		if s.StemForms == nil {
+			s.StemForms = make(map[string]int)
+		}
+		for w, n := range other.StemForms {
+			s.StemForms[w] += n
+		}
+	}
+}
+
+// Вивід результатів у форматі, звичному для програми
+func printStats(stats textStats, opts options) {
+	if stats.Words == 0 {
+		fmt.Println("У тексті немає слів для аналізу.")
+		return
+	}
+
+	// Вивід результатів пошуку слова
+	if opts.Word != "" {
+		if stats.WordCount == 0 {
+			fmt.Printf("Слово або літера \"%s\" не знайдено у тексті.\n", opts.Word)
+		} else {
+			fmt.Printf("Слово або літера \"%s\" зустрічається %d раз(и).\n", opts.Word, stats.WordCount)
+		}
+	}
+
+	if opts.Match != nil {
+		fmt.Printf("Пошук \"%s\": знайдено %d збіг(ів).\n", opts.Search, len(stats.Matches))
+	}
+	if opts.StemKey != "" {
+		printStemForms(stats.StemForms, opts.Stem)
+	}
+
+	if opts.Lang {
+		fmt.Println("Мова тексту:", detectLanguage(stats.Trigrams))
+	}
+
+	// Вивід загальної кількості слів та найдовшого слова
+	fmt.Printf("У тексті всього %d слів.\n", stats.Words)
+	fmt.Printf("Найдовше слово тексту: %s (%d символів)\n", stats.LongestWord, utf8.RuneCountInString(stats.LongestWord))
+
+	if opts.Letter != "" {
+		if stats.FirstWithLetter == "" {
+			fmt.Printf("Слів, що починаються на \"%s\", не знайдено.\n", opts.Letter)
+		} else {
+			fmt.Printf("Перше слово, що починається на \"%s\": %s\n", opts.Letter, stats.FirstWithLetter)
+		}
+	}
+
+	printReadability(stats)
+}
+
+// Виводить усі знайдені форми слова за спаданням кількості
+func printStemForms(forms map[string]int, word string) {
+	if len(forms) == 0 {
+		fmt.Printf("Форм слова \"%s\" не знайдено у тексті.\n", word)
+		return
+	}
+
+	list := make([]wordFreq, 0, len(forms))
+	total := 0
+	for w, n := range forms {
+		list = append(list, wordFreq{Word: w, Count: n})
+		total += n
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Word < list[j].Word
+	})
+
+	fmt.Printf("Форми слова \"%s\": %d входжень, %d форм(и)\n", word, total, len(list))
+	for _, f := range list {
+		fmt.Printf("  %s — %d\n", f.Word, f.Count)
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import "strings"
+
+// ---------- Стемер Porter2 (англійська) ----------
+// Реалізація алгоритму Snowball English (Porter2):
+// https://snowballstem.org/algorithms/english/stemmer.html
+// Працює з нормалізованими словами (нижній регістр, апостроф ').
+
+// Слова, що стемуються нестандартно або не змінюються
+var porter2Exceptions = map[string]string{
+	"skis": "ski", "skies": "sky", "dying": "die", "lying": "lie", "tying": "tie",
+	"idly": "idl", "gently": "gentl", "ugly": "ugli", "early": "earli", "only": "onli",
+	"singly": "singl", "sky": "sky", "news": "news", "howe": "howe",
+	"atlas": "atlas", "cosmos": "cosmos", "bias": "bias", "andes": "andes",
+}
+
+// Після кроку 1a ці слова більше не змінюються
+var porter2Exceptions1a = map[string]bool{
+	"inning": true, "outing": true, "canning": true, "herring": true,
+	"earring": true, "proceed": true, "exceed": true, "succeed": true,
+}
+
+func isVowelEN(r byte) bool {
+	return strings.IndexByte("aeiouy", r) != -1
+}
+
+// Стемер працює з байтами: Y позначає приголосну y
+type porter2 struct {
+	w      []byte
+	r1, r2 int
+}
+
+func stemEnglish(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	if s, ok := porter2Exceptions[word]; ok {
+		return s
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] >= 0x80 {
+			return word // не латиниця — не чіпаємо
+		}
+	}
+
+	p := &porter2{w: []byte(strings.TrimPrefix(word, "'"))}
+	p.markConsonantY()
+	p.computeRegions()
+
+	p.step0()
+	p.step1a()
+	if porter2Exceptions1a[string(p.w)] {
+		return string(p.w)
+	}
+	p.step1b()
+	p.step1c()
+	p.step2()
+	p.step3()
+	p.step4()
+	p.step5()
+
+	return strings.ReplaceAll(string(p.w), "Y", "y")
+}
+
+func (p *porter2) isVowel(i int) bool {
+	return isVowelEN(p.w[i])
+}
+
+// y на початку слова або після голосної — приголосна
+func (p *porter2) markConsonantY() {
+	for i := range p.w {
+		if p.w[i] == 'y' && (i == 0 || p.isVowel(i-1)) {
+			p.w[i] = 'Y'
+		}
+	}
+}
+
+// R1 — після першої приголосної, що йде за голосною; R2 — те саме всередині R1
+func (p *porter2) computeRegions() {
+	p.r1 = len(p.w)
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(p.w), prefix) {
+			p.r1 = len(prefix)
+			break
+		}
+	}
+	if p.r1 == len(p.w) {
+		p.r1 = p.regionAfter(0)
+	}
+	p.r2 = p.regionAfter(p.r1)
+}
+
+func (p *porter2) regionAfter(start int) int {
+	for i := start + 1; i < len(p.w); i++ {
+		if !p.isVowel(i) && p.isVowel(i-1) {
+			return i + 1
+		}
+	}
+	return len(p.w)
+}
+
+func (p *porter2) hasSuffix(s string) bool {
+	return strings.HasSuffix(string(p.w), s)
+}
+
+// Найдовший суфікс зі списку, яким закінчується слово
+func (p *porter2) longestSuffix(suffixes ...string) string {
+	best := ""
+	for _, s := range suffixes {
+		if len(s) > len(best) && p.hasSuffix(s) {
+			best = s
+		}
+	}
+	return best
+}
+
+func (p *porter2) replace(suffix, with string) {
+	p.w = append(p.w[:len(p.w)-len(suffix)], with...)
+}
+
+func (p *porter2) inR1(suffix string) bool { return len(p.w)-len(suffix) >= p.r1 }
+func (p *porter2) inR2(suffix string) bool { return len(p.w)-len(suffix) >= p.r2 }
+
+// Чи є голосна в p.w[:end]
+func (p *porter2) hasVowelBefore(end int) bool {
+	for i := 0; i < end; i++ {
+		if p.isVowel(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// Коротким складом закінчується частина слова p.w[:end]
+func (p *porter2) endsShortSyllable(end int) bool {
+	if end == 2 {
+		return p.isVowel(0) && !p.isVowel(1)
+	}
+	if end < 3 {
+		return false
+	}
+	c := p.w[end-1]
+	return !p.isVowel(end-3) && p.isVowel(end-2) && !p.isVowel(end-1) &&
+		c != 'w' && c != 'x' && c != 'Y'
+}
+
+func (p *porter2) isShort() bool {
+	return p.r1 >= len(p.w) && p.endsShortSyllable(len(p.w))
+}
+
+func (p *porter2) step0() {
+	if s := p.longestSuffix("'s'", "'s", "'"); s != "" {
+		p.replace(s, "")
+	}
+}
+
+func (p *porter2) step1a() {
+	switch s := p.longestSuffix("sses", "ied", "ies", "us", "ss", "s"); s {
+	case "sses":
+		p.replace(s, "ss")
+	case "ied", "ies":
+		if len(p.w) > 4 {
+			p.replace(s, "i")
+		} else {
+			p.replace(s, "ie")
+		}
+	case "s":
+		// Видаляємо, якщо голосна є раніше, ніж безпосередньо перед s
+		if p.hasVowelBefore(len(p.w) - 2) {
+			p.replace(s, "")
+		}
+	}
+}
+
+func (p *porter2) step1b() {
+	switch s := p.longestSuffix("eed", "eedly", "ed", "edly", "ing", "ingly"); s {
+	case "":
+	case "eed", "eedly":
+		if p.inR1(s) {
+			p.replace(s, "ee")
+		}
+	default:
+		if !p.hasVowelBefore(len(p.w) - len(s)) {
+			return
+		}
+		p.replace(s, "")
+		switch {
+		case p.hasSuffix("at") || p.hasSuffix("bl") || p.hasSuffix("iz"):
+			p.w = append(p.w, 'e')
+		case p.endsDouble():
+			p.w = p.w[:len(p.w)-1]
+		case p.isShort():
+			p.w = append(p.w, 'e')
+		}
+	}
+}
+
+func (p *porter2) endsDouble() bool {
+	for _, d := range []string{"bb", "dd", "ff", "gg", "mm", "nn", "pp", "rr", "tt"} {
+		if p.hasSuffix(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *porter2) step1c() {
+	n := len(p.w)
+	if n > 2 && (p.w[n-1] == 'y' || p.w[n-1] == 'Y') && !p.isVowel(n-2) {
+		p.w[n-1] = 'i'
+	}
+}
+
+var porter2Step2 = map[string]string{
+	"tional": "tion", "enci": "ence", "anci": "ance", "abli": "able", "entli": "ent",
+	"izer": "ize", "ization": "ize", "ational": "ate", "ation": "ate", "ator": "ate",
+	"alism": "al", "aliti": "al", "alli": "al", "fulness": "ful", "ousli": "ous",
+	"ousness": "ous", "iveness": "ive", "iviti": "ive", "biliti": "ble", "bli": "ble",
+	"ogi": "og", "fulli": "ful", "lessli": "less", "li": "",
+}
+
+func (p *porter2) step2() {
+	suffixes := make([]string, 0, len(porter2Step2))
+	for s := range porter2Step2 {
+		suffixes = append(suffixes, s)
+	}
+	s := p.longestSuffix(suffixes...)
+	if s == "" || !p.inR1(s) {
+		return
+	}
+	before := byte(0)
+	if len(p.w) > len(s) {
+		before = p.w[len(p.w)-len(s)-1]
+	}
+	switch s {
+	case "ogi":
+		if before != 'l' {
+			return
+		}
+	case "li":
+		if strings.IndexByte("cdeghkmnrt", before) == -1 || before == 0 {
+			return
+		}
+	}
+	p.replace(s, porter2Step2[s])
+}
+
+var porter2Step3 = map[string]string{
+	"tional": "tion", "ational": "ate", "alize": "al", "icate": "ic",
+	"iciti": "ic", "ical": "ic", "ful": "", "ness": "", "ative": "",
+}
+
+func (p *porter2) step3() {
+	suffixes := make([]string, 0, len(porter2Step3))
+	for s := range porter2Step3 {
+		suffixes = append(suffixes, s)
+	}
+	s := p.longestSuffix(suffixes...)
+	if s == "" || !p.inR1(s) {
+		return
+	}
+	if s == "ative" && !p.inR2(s) {
+		return
+	}
+	p.replace(s, porter2Step3[s])
+}
+
+func (p *porter2) step4() {
+	s := p.longestSuffix("al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement",
+		"ment", "ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion")
+	if s == "" || !p.inR2(s) {
+		return
+	}
+	if s == "ion" {
+		if n := len(p.w) - len(s); n == 0 || (p.w[n-1] != 's' && p.w[n-1] != 't') {
+			return
+		}
+	}
+	p.replace(s, "")
+}
+
+func (p *porter2) step5() {
+	switch {
+	case p.hasSuffix("e"):
+		if p.inR2("e") || (p.inR1("e") && !p.endsShortSyllable(len(p.w)-1)) {
+			p.replace("e", "")
+		}
+	case p.hasSuffix("l"):
+		if p.inR2("l") && len(p.w) > 1 && p.w[len(p.w)-2] == 'l' {
+			p.replace("l", "")
+		}
+	}
+}
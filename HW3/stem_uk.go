@@ -0,0 +1,210 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ---------- Стемер для української мови ----------
+// Відсікання закінчень у стилі Snowball: робоча зона RV починається після
+// першої голосної; спершу прибираються дієприслівникові та зворотні
+// закінчення, потім прикметникові, дієслівні або іменникові, наприкінці —
+// суфікс -ість та м'який знак. Це легкий стемер без словника, тож
+// чергування голосних у корені (кіт/кота) ним не усувається — для пошуку
+// є stemKeyUK.
+
+const vowelsUK = "аеиоуюяіїє"
+
+var (
+	gerundEndingsUK    = []string{"вшись", "вши", "ши", "ючи", "учи", "ачи", "ячи"}
+	reflexiveEndingsUK = []string{"ся", "сь"}
+	adjectiveEndingsUK = []string{
+		"ього", "ьому", "ьої", "ьою",
+		"ого", "ому", "ими", "іми", "ої", "ою", "ій", "ий", "їй", "им", "ім", "их", "іх",
+	}
+	// Дієслівні закінчення відкидаються лише після голосної основи
+	// (чита-ти, чита-ю, роби-ла), щоб не зачепити іменники ("кот-и", "сел-о")
+	verbEndingsUK = []string{
+		"ти", "ть", "ла", "ло", "ли", "в",
+		"ю", "ють", "ємо", "єте", "єш", "є", "ймо", "йте", "й",
+		"мо", "те", "ш",
+	}
+	nounEndingsUK = []string{
+		"ями", "ами", "ові", "еві", "єві", "ях", "ах", "ів", "їв", "ей", "ем", "єм",
+		"ом", "ою", "ею", "єю", "ям", "ам",
+		"а", "я", "о", "е", "є", "у", "ю", "і", "ї", "и", "й", "ь",
+	}
+)
+
+func isVowelUK(r rune) bool {
+	return strings.ContainsRune(vowelsUK, r)
+}
+
+// Прибирає найдовше закінчення зі списку, якщо воно повністю в зоні RV.
+// Повертає слово та ознаку, чи щось було відкинуто.
+func cutEnding(word []rune, rv int, endings []string) ([]rune, bool) {
+	s := string(word)
+	best := ""
+	for _, e := range endings {
+		if len(e) > len(best) && strings.HasSuffix(s, e) {
+			best = e
+		}
+	}
+	if best == "" {
+		return word, false
+	}
+	n := len(word) - len([]rune(best))
+	if n < rv {
+		return word, false
+	}
+	return word[:n], true
+}
+
+// Голосні, якими закінчуються дієслівні основи
+const verbStemVowelsUK = "аяиіїує"
+
+// Відкидає дієслівне або іменникове закінчення — найдовше з двох.
+// Дієслівне враховується лише після голосної основи.
+func cutVerbOrNounEnding(word []rune, rv int) []rune {
+	noun, _ := cutEnding(word, rv, nounEndingsUK)
+	verb, ok := cutEnding(word, rv, verbEndingsUK)
+	if ok && len(verb) > 0 && strings.ContainsRune(verbStemVowelsUK, verb[len(verb)-1]) && len(verb) < len(noun) {
+		return verb
+	}
+	return noun
+}
+
+func stemUkrainian(word string) string {
+	runes := []rune(strings.ReplaceAll(word, "'", ""))
+
+	rv := len(runes)
+	for i, r := range runes {
+		if isVowelUK(r) {
+			rv = i + 1
+			break
+		}
+	}
+	// Надто короткі слова не стемуємо: від них майже нічого не лишиться
+	if rv >= len(runes) || len(runes) <= 3 {
+		return word
+	}
+
+	// Крок 1: дієприслівник, інакше зворотна частка + прикметник/дієслово/іменник
+	if w, ok := cutEnding(runes, rv, gerundEndingsUK); ok {
+		runes = w
+	} else {
+		runes, _ = cutEnding(runes, rv, reflexiveEndingsUK)
+		if w, ok := cutEnding(runes, rv, adjectiveEndingsUK); ok {
+			runes = w
+		} else {
+			runes = cutVerbOrNounEnding(runes, rv)
+		}
+	}
+
+	// Крок 2: словотвірний суфікс -ість ("радість" -> "рад")
+	if w, ok := cutEnding(runes, rv, []string{"ість", "іст", "ост"}); ok && len(w) >= 3 {
+		runes = w
+	}
+
+	// Крок 3: подвоєна "нн" та м'який знак у кінці
+	s := string(runes)
+	s = strings.TrimSuffix(s, "ь")
+	if strings.HasSuffix(s, "нн") {
+		s = strings.TrimSuffix(s, "н")
+	}
+	return s
+}
+
+// Слова, в яких і в останньому закритому складі чергується з о або е
+// в інших формах. Чи є чергування і з якою голосною — властивість слова,
+// а не правило: кіт — кота, але кета; ліс — лісу, а лосі — від "лось";
+// сіль — солі, а не село. Тому слова перелічено явно.
+var alternationUK = map[string]rune{
+	"кіт": 'о', "ніч": 'о', "сіль": 'о', "ніс": 'о', "віз": 'о', "стіл": 'о',
+	"міст": 'о', "ріг": 'о', "бік": 'о', "двір": 'о', "кінь": 'о', "рік": 'о',
+	"сік": 'о', "ніж": 'о', "біль": 'о', "хід": 'о', "схід": 'о', "вхід": 'о',
+	"вихід": 'о', "похід": 'о', "захід": 'о', "дім": 'о', "гість": 'о',
+	"ріст": 'о', "сніп": 'о', "рів": 'о', "вечір": 'о', "львів": 'о',
+	"піч": 'е', "річ": 'е', "осінь": 'е', "шість": 'е', "камінь": 'е',
+	"корінь": 'е', "попіл": 'е', "лебідь": 'е', "ведмідь": 'е', "сім": 'е',
+}
+
+// Ключ для порівняння основ з урахуванням змін голосної в останньому
+// закритому складі основи (лише в ньому — решта основи не змінюється).
+// Чергування і з о/е для слів з alternationUK: "кіт" і "кот(а)", "піч" і
+// "печ(і)" — ключем слугує основа з о/е. Випадні о/е: "вікон" і "вікн(а)",
+// "вітер" і "вітр(у)"; голосна викидається, лише якщо в основі лишається
+// інша голосна, інакше "кот" і "кет" дали б однаковий ключ "кт".
+func stemKeyUK(word, stem string) string {
+	runes := []rune(stem)
+	i := lastClosedVowel(runes)
+	if i == -1 {
+		return stem
+	}
+
+	// і буває лише в закритому складі, тож чергування стосується форми без
+	// закінчення: "кіт", "сіль", а не "білий" з тією самою основою "біл"
+	if v, ok := alternationUK[word]; ok && runes[i] == 'і' &&
+		(word == stem || word == stem+"ь") {
+		runes[i] = v
+	}
+
+	if (runes[i] == 'о' || runes[i] == 'е') && i > 0 && !isVowelUK(runes[i-1]) &&
+		i == len(runes)-2 && strings.IndexFunc(string(runes[:i]), isVowelUK) != -1 {
+		return string(runes[:i]) + string(runes[i+1:])
+	}
+	return string(runes)
+}
+
+// Позиція голосної останнього складу, якщо основа закінчується приголосною
+// (склад закритий), інакше -1
+func lastClosedVowel(runes []rune) int {
+	if len(runes) == 0 || isVowelUK(runes[len(runes)-1]) {
+		return -1
+	}
+	for i := len(runes) - 2; i >= 0; i-- {
+		if isVowelUK(runes[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Визначає мову слова за першою літерою: кирилиця — uk, інакше — en
+func wordLang(word string) string {
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return "uk"
+		}
+		if unicode.IsLetter(r) {
+			return "en"
+		}
+	}
+	return ""
+}
+
+// Основа слова для вказаної мови ("" — визначити за алфавітом)
+func stemWord(word, lang string) string {
+	if lang == "" {
+		lang = wordLang(word)
+	}
+	switch lang {
+	case "uk":
+		return stemUkrainian(word)
+	case "en":
+		return stemEnglish(word)
+	}
+	return word
+}
+
+// Ключ для пошуку за основою: для української враховує чергування голосних
+func stemKey(word, lang string) string {
+	if lang == "" {
+		lang = wordLang(word)
+	}
+	stem := stemWord(word, lang)
+	if lang == "uk" {
+		return stemKeyUK(normalizeWord(word), stem)
+	}
+	return stem
+}
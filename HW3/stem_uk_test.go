@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestStemKeyUK(t *testing.T) {
+	same := [][2]string{
+		{"кіт", "кота"}, {"кіт", "котові"}, {"кіт", "котів"},
+		{"піч", "печі"}, {"ніч", "ночі"}, {"сіль", "солі"},
+		{"вікон", "вікна"}, {"вітер", "вітру"}, {"вечір", "вечора"},
+		{"камінь", "каменя"}, {"кінь", "коня"},
+	}
+	for _, p := range same {
+		if a, b := stemKey(p[0], ""), stemKey(p[1], ""); a != b {
+			t.Errorf("stemKey(%q) = %q, stemKey(%q) = %q, очікувався однаковий ключ", p[0], a, p[1], b)
+		}
+	}
+
+	different := [][2]string{
+		{"кіт", "кет"}, {"кіт", "кета"}, {"ліс", "лосі"}, {"ліс", "лось"},
+		{"сіль", "село"}, {"море", "мір"}, {"мір", "мера"}, {"море", "мера"},
+		{"біль", "білий"}, {"кота", "кета"},
+	}
+	for _, p := range different {
+		if a, b := stemKey(p[0], ""), stemKey(p[1], ""); a == b {
+			t.Errorf("stemKey(%q) = stemKey(%q) = %q, очікувалися різні ключі", p[0], p[1], a)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ---------- Ключові слова та стислий виклад ----------
+// Ключові слова двома способами:
+// - TF-IDF: частота слова в документі, помножена на IDF з еталонного
+//   корпусу (-reference); без корпусу "документами" для IDF слугують
+//   речення самого тексту. Форми слова об'єднуються за основою ("кіт",
+//   "кота", "котові"), показується найчастіша форма;
+// - RAKE: кандидати — послідовності слів без стоп-слів і розділових знаків
+//   (не довші за rakeMaxWords); вага слова — deg/freq, де deg — сумарна
+//   довжина фраз, у яких воно трапляється; вага фрази — сума ваг слів.
+//
+// Стислий виклад — TextRank: речення є вершинами графа, вага ребра —
+// схожість речень |спільні основи| / (log|A| + log|B|); ранг обчислюється
+// ітераціями PageRank (коефіцієнт згасання 0.85). Вибрані речення
+// виводяться в порядку появи в тексті.
+
+const (
+	rakeMaxWords    = 4
+	textRankDamping = 0.85
+	textRankIters   = 100
+	textRankEpsilon = 1e-6
+)
+
+// Речення документа для виділення ключових слів та викладу
+type summarySentence struct {
+	Line   int
+	Text   string
+	Words  []token
+	Breaks map[int]bool // перед якими словами є розділовий знак або число
+}
+
+// Читає документ і ділить його на речення
+func readSentences(in input) ([]summarySentence, error) {
+	r, err := in.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var sentences []summarySentence
+	cur := summarySentence{Breaks: make(map[int]bool)}
+	split := sentenceSplitter{
+		onToken: func(t token) {
+			if t.Kind == tokenWord {
+				cur.Words = append(cur.Words, t)
+			} else {
+				cur.Breaks[len(cur.Words)] = true
+			}
+		},
+		onClause: func() { cur.Breaks[len(cur.Words)] = true },
+		onEnd: func(line int, text string) {
+			cur.Line, cur.Text = line, text
+			sentences = append(sentences, cur)
+			cur = summarySentence{Breaks: make(map[int]bool)}
+		},
+	}
+
+	reader := bufio.NewReader(r)
+	lineNo := 0
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			lineNo++
+			split.addLine(lineNo, line, tokenizeLine(line, lineNo, 0))
+		}
+		if err == io.EOF {
+			split.end()
+			return sentences, nil
+		}
+		if err != nil {
+			return sentences, err
+		}
+	}
+}
+
+// Основи значущих слів речення (без стоп-слів і однолітерних)
+func sentenceStems(s summarySentence, stop map[string]bool) map[string]bool {
+	stems := make(map[string]bool)
+	for _, t := range s.Words {
+		if !stop[t.Text] && len([]rune(t.Text)) > 1 {
+			stems[stemWord(t.Text, "")] = true
+		}
+	}
+	return stems
+}
+
+// Документна частота основ у еталонному корпусі
+type referenceCorpus struct {
+	Docs int
+	DF   map[string]int
+}
+
+func loadReferenceCorpus(inputs []input) (referenceCorpus, error) {
+	ref := referenceCorpus{DF: make(map[string]int)}
+	for _, in := range inputs {
+		r, err := in.Open()
+		if err != nil {
+			return ref, err
+		}
+		seen := make(map[string]bool)
+		err = eachWord(r, func(t token) bool {
+			seen[stemWord(t.Text, "")] = true
+			return true
+		})
+		r.Close()
+		if err != nil {
+			return ref, fmt.Errorf("%s: %w", in.Name, err)
+		}
+		for stem := range seen {
+			ref.DF[stem]++
+		}
+		ref.Docs++
+	}
+	return ref, nil
+}
+
+// Ключове слово або фраза
+type keyword struct {
+	Text  string
+	Score float64
+	Count int
+}
+
+func sortKeywords(list []keyword, top int) []keyword {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Score != list[j].Score {
+			return list[i].Score > list[j].Score
+		}
+		return list[i].Text < list[j].Text
+	})
+	if top > 0 && len(list) > top {
+		list = list[:top]
+	}
+	return list
+}
+
+// Ключові слова за TF-IDF. Якщо корпус порожній, IDF рахується по реченнях.
+func tfidfKeywords(sentences []summarySentence, stop map[string]bool, ref referenceCorpus, top int) []keyword {
+	if ref.Docs == 0 {
+		ref = referenceCorpus{DF: make(map[string]int)}
+		for _, s := range sentences {
+			for stem := range sentenceStems(s, stop) {
+				ref.DF[stem]++
+			}
+			ref.Docs++
+		}
+	}
+
+	tf := make(map[string]int)
+	forms := make(map[string]map[string]int) // основа -> форми та їх частоти
+	for _, s := range sentences {
+		for _, t := range s.Words {
+			if stop[t.Text] || len([]rune(t.Text)) < 3 {
+				continue
+			}
+			stem := stemWord(t.Text, "")
+			tf[stem]++
+			if forms[stem] == nil {
+				forms[stem] = make(map[string]int)
+			}
+			forms[stem][t.Text]++
+		}
+	}
+
+	n := float64(ref.Docs)
+	list := make([]keyword, 0, len(tf))
+	for stem, count := range tf {
+		idf := math.Log((1+n)/(1+float64(ref.DF[stem]))) + 1
+		list = append(list, keyword{Text: mostFrequent(forms[stem]), Score: float64(count) * idf, Count: count})
+	}
+	return sortKeywords(list, top)
+}
+
+func mostFrequent(counts map[string]int) string {
+	best := ""
+	for w, n := range counts {
+		if best == "" || n > counts[best] || (n == counts[best] && w < best) {
+			best = w
+		}
+	}
+	return best
+}
+
+// Ключові фрази за RAKE
+func rakeKeywords(sentences []summarySentence, stop map[string]bool, top int) []keyword {
+	var phrases [][]string
+	for _, s := range sentences {
+		var phrase []string
+		flush := func() {
+			if len(phrase) > 0 && len(phrase) <= rakeMaxWords {
+				phrases = append(phrases, phrase)
+			}
+			phrase = nil
+		}
+		for i, t := range s.Words {
+			if s.Breaks[i] {
+				flush()
+			}
+			if stop[t.Text] || len([]rune(t.Text)) < 2 {
+				flush()
+				continue
+			}
+			phrase = append(phrase, t.Text)
+		}
+		flush()
+	}
+
+	freq := make(map[string]int)
+	degree := make(map[string]int)
+	for _, p := range phrases {
+		for _, w := range p {
+			freq[w]++
+			degree[w] += len(p)
+		}
+	}
+
+	byText := make(map[string]*keyword)
+	var order []string
+	for _, p := range phrases {
+		text := strings.Join(p, " ")
+		if k, ok := byText[text]; ok {
+			k.Count++
+			continue
+		}
+		score := 0.0
+		for _, w := range p {
+			score += float64(degree[w]) / float64(freq[w])
+		}
+		byText[text] = &keyword{Text: text, Score: score, Count: 1}
+		order = append(order, text)
+	}
+
+	list := make([]keyword, 0, len(order))
+	for _, text := range order {
+		list = append(list, *byText[text])
+	}
+	return sortKeywords(list, top)
+}
+
+// Ранги речень за TextRank
+func textRank(sentences []summarySentence, stop map[string]bool) []float64 {
+	n := len(sentences)
+	stems := make([]map[string]bool, n)
+	byStem := make(map[string][]int) // основа -> речення, де вона є
+	for i, s := range sentences {
+		stems[i] = sentenceStems(s, stop)
+		for stem := range stems[i] {
+			byStem[stem] = append(byStem[stem], i)
+		}
+	}
+
+	// Ребра лише між реченнями зі спільними основами
+	edges := make([]map[int]float64, n)
+	for i := range edges {
+		edges[i] = make(map[int]float64)
+	}
+	for i := range sentences {
+		common := make(map[int]int)
+		for stem := range stems[i] {
+			for _, j := range byStem[stem] {
+				if j > i {
+					common[j]++
+				}
+			}
+		}
+		for j, c := range common {
+			denom := math.Log(float64(len(stems[i]))) + math.Log(float64(len(stems[j])))
+			if denom <= 0 {
+				denom = 1
+			}
+			edges[i][j] = float64(c) / denom
+			edges[j][i] = edges[i][j]
+		}
+	}
+
+	outWeight := make([]float64, n)
+	for i, e := range edges {
+		for _, w := range e {
+			outWeight[i] += w
+		}
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1
+	}
+	for iter := 0; iter < textRankIters; iter++ {
+		next := make([]float64, n)
+		delta := 0.0
+		for i := range next {
+			sum := 0.0
+			for j, w := range edges[i] {
+				sum += w / outWeight[j] * rank[j]
+			}
+			next[i] = 1 - textRankDamping + textRankDamping*sum
+			delta = max(delta, math.Abs(next[i]-rank[i]))
+		}
+		rank = next
+		if delta < textRankEpsilon {
+			break
+		}
+	}
+	return rank
+}
+
+// Індекси k найважливіших речень у порядку появи в тексті
+func summarize(sentences []summarySentence, stop map[string]bool, k int) []int {
+	rank := textRank(sentences, stop)
+	idx := make([]int, len(sentences))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return rank[idx[a]] > rank[idx[b]] })
+	if len(idx) > k {
+		idx = idx[:k]
+	}
+	sort.Ints(idx)
+	return idx
+}
+
+// Виводить ключові слова та/або стислий виклад документа
+func writeSummary(w io.Writer, name string, sentences []summarySentence, stop map[string]bool, ref referenceCorpus, keywords, summary int) {
+	if keywords > 0 {
+		fmt.Fprintf(w, "\n--- Ключові слова: %s ---\n", name)
+		source := "речення документа"
+		if ref.Docs > 0 {
+			source = fmt.Sprintf("еталонний корпус, документів: %d", ref.Docs)
+		}
+		fmt.Fprintf(w, "TF-IDF (IDF: %s):\n", source)
+		writeKeywords(w, tfidfKeywords(sentences, stop, ref, keywords))
+		fmt.Fprintln(w, "RAKE:")
+		writeKeywords(w, rakeKeywords(sentences, stop, keywords))
+	}
+
+	if summary > 0 {
+		picked := summarize(sentences, stop, summary)
+		fmt.Fprintf(w, "\n--- Стислий виклад: %s (%d з %d речень) ---\n", name, len(picked), len(sentences))
+		for _, i := range picked {
+			fmt.Fprintf(w, "[рядок %d] %s\n", sentences[i].Line, sentences[i].Text)
+		}
+	}
+}
+
+func writeKeywords(w io.Writer, list []keyword) {
+	width := 0
+	for _, k := range list {
+		width = max(width, utf8.RuneCountInString(k.Text))
+	}
+	for _, k := range list {
+		pad := strings.Repeat(" ", width-utf8.RuneCountInString(k.Text))
+		fmt.Fprintf(w, "  %s%s %8.2f  (%d×)\n", k.Text, pad, k.Score, k.Count)
+	}
+}
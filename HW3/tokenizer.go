@@ -0,0 +1,193 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------- Токенізатор ----------
+// Розбиває текст на слова та числа за категоріями Unicode:
+// - слово — послідовність літер (L*) та діакритик (M*), може містити цифри
+//   ("mp3") і починається з літери;
+// - апостроф (' ’ ʼ ‘ `) між літерами — частина слова: "м'ята", "п’ять", "don't"
+//   (для кирилиці — лише перед я, ю, є, ї, див. apostropheJoins);
+// - дефіс (- ‐ ‑) між літерами — частина слова: "будь-який", "well-known";
+//   тире (– —) завжди розділяє;
+// - число — цифри з десятковим роздільником ("3.14", "1,5") та суфіксом
+//   порядкового числівника ("5-й", "10th"); числа не вважаються словами;
+// - усе інше (пробіли, «», —, …, інші знаки P* та S*) — роздільники.
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenNumber
+)
+
+type token struct {
+	Text   string // нормалізований вигляд: нижній регістр, апостроф — '
+	Raw    string // як у тексті
+	Kind   tokenKind
+	Line   int // номер рядка (з 1)
+	Col    int // номер символу в рядку (з 1)
+	Offset int // зсув у байтах від початку тексту
+}
+
+// Канонічний апостроф, до якого зводяться всі варіанти
+const apostrophe = '\''
+
+func isApostrophe(r rune) bool {
+	switch r {
+	case '\'', '’', 'ʼ', '‘', '`':
+		return true
+	}
+	return false
+}
+
+func isHyphen(r rune) bool {
+	return r == '-' || r == '‐' || r == '‑'
+}
+
+// Чи є апостроф між prev та next частиною слова. В українській апостроф
+// ставиться лише перед я, ю, є, ї ("м'ята", "п'ять", "з'їзд"), тому в
+// кириличному слові інші випадки вважаються лапками. Для латиниці досить,
+// щоб з обох боків були літери ("don't", "O'Brien").
+func apostropheJoins(prev, next rune) bool {
+	if !isWordRune(prev) || !isWordRune(next) {
+		return false
+	}
+	if unicode.Is(unicode.Cyrillic, prev) {
+		return strings.ContainsRune("яюєїЯЮЄЇ", next)
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r)
+}
+
+// Зводить слово до нормалізованого вигляду (для пошуку та порівняння)
+func normalizeWord(w string) string {
+	return strings.Map(func(r rune) rune {
+		if isApostrophe(r) {
+			return apostrophe
+		}
+		if r == '‐' || r == '‑' {
+			return '-'
+		}
+		return unicode.ToLower(r)
+	}, w)
+}
+
+// Розбиває весь текст на токени
+func tokenize(text string) []token {
+	var tokens []token
+	line, offset := 1, 0
+	for len(text) > 0 {
+		end := strings.IndexByte(text, '\n')
+		if end == -1 {
+			end = len(text) - 1
+		}
+		tokens = append(tokens, tokenizeLine(text[:end+1], line, offset)...)
+		offset += end + 1
+		text = text[end+1:]
+		line++
+	}
+	return tokens
+}
+
+// Розбиває один рядок. lineNo та offset задають позицію рядка в тексті.
+func tokenizeLine(line string, lineNo, offset int) []token {
+	var tokens []token
+	runes := []rune(line)
+
+	// Байтові зсуви кожного символу, щоб повертати Offset
+	byteAt := make([]int, len(runes)+1)
+	for i, pos := 0, 0; i < len(runes); i++ {
+		byteAt[i] = pos
+		pos += utf8.RuneLen(runes[i])
+	}
+	byteAt[len(runes)] = len(line)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		var kind tokenKind
+		switch {
+		case isWordRune(r):
+			kind = tokenWord
+			i = scanWord(runes, i)
+		case unicode.IsDigit(r):
+			kind = tokenNumber
+			i = scanNumber(runes, i)
+		default:
+			i++
+			continue
+		}
+
+		raw := string(runes[start:i])
+		tokens = append(tokens, token{
+			Text:   normalizeWord(raw),
+			Raw:    raw,
+			Kind:   kind,
+			Line:   lineNo,
+			Col:    start + 1,
+			Offset: offset + byteAt[start],
+		})
+	}
+	return tokens
+}
+
+// Повертає індекс першого символу після слова, що починається з i
+func scanWord(runes []rune, i int) int {
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case isWordRune(r) || unicode.IsDigit(r):
+			i++
+		case isApostrophe(r) && i+1 < len(runes) && apostropheJoins(runes[i-1], runes[i+1]):
+			i += 2
+		case isHyphen(r) && i+1 < len(runes) && isWordRune(runes[i+1]):
+			// Дефіс лише між літерами; інакше це тире або перенос
+			i += 2
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// Повертає індекс першого символу після числа, що починається з i
+func scanNumber(runes []rune, i int) int {
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		i++
+	}
+	// Десяткова частина: "3.14", "1,5"
+	if i+1 < len(runes) && (runes[i] == '.' || runes[i] == ',') && unicode.IsDigit(runes[i+1]) {
+		i++
+		for i < len(runes) && unicode.IsDigit(runes[i]) {
+			i++
+		}
+	}
+	// Суфікс порядкового числівника: "5-й", "10th"
+	if i+1 < len(runes) && isHyphen(runes[i]) && isWordRune(runes[i+1]) {
+		i++
+	}
+	for i < len(runes) && isWordRune(runes[i]) {
+		i++
+	}
+	return i
+}
+
+// Повертає лише слова (нормалізовані) з рядка
+func lineWords(line string) []string {
+	var words []string
+	for _, t := range tokenizeLine(line, 0, 0) {
+		if t.Kind == tokenWord {
+			words = append(words, t.Text)
+		}
+	}
+	return words
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// ---------- Транслітерація ----------
+// Таблиця транслітерації українського алфавіту латиницею, затверджена
+// постановою Кабінету Міністрів України від 27 січня 2010 р. № 55:
+// - є, ї, й, ю, я на початку слова передаються як Ye, Yi, Y, Yu, Ya,
+//   в інших позиціях — як ie, i, i, iu, ia (Єнакієве — Yenakiieve);
+// - сполучення "зг" передається як "zgh" (Згорани — Zghorany), щоб
+//   відрізнити його від "ж" (zh);
+// - м'який знак і апостроф не передаються (Русь — Rus, Знам'янка — Znamianka).
+// Інші символи (латиниця, цифри, розділові знаки) залишаються без змін.
+//
+// Режим slug готує рядок для URL: транслітерація, нижній регістр, усе, крім
+// латинських літер і цифр, замінюється одним дефісом.
+
+var translitTable = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e",
+	'є': "ie", 'ж': "zh", 'з': "z", 'и': "y", 'і': "i", 'ї': "i", 'й': "i",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch",
+	'ш': "sh", 'щ': "shch", 'ь': "", 'ю': "iu", 'я': "ia",
+}
+
+// Написання на початку слова
+var translitInitial = map[rune]string{
+	'є': "ye", 'ї': "yi", 'й': "y", 'ю': "yu", 'я': "ya",
+}
+
+// Транслітерує текст за офіційною таблицею
+func transliterate(text string) string {
+	runes := []rune(text)
+	var sb strings.Builder
+	sb.Grow(len(text))
+
+	for i, r := range runes {
+		lower := unicode.ToLower(r)
+		if isApostrophe(r) && betweenLetters(runes, i) {
+			continue
+		}
+		latin, ok := translitTable[lower]
+		if !ok {
+			sb.WriteRune(r)
+			continue
+		}
+		if initial, ok := translitInitial[lower]; ok && wordStartsAt(runes, i) {
+			latin = initial
+		}
+		if lower == 'г' && i > 0 && unicode.ToLower(runes[i-1]) == 'з' {
+			latin = "gh"
+		}
+
+		if latin != "" && unicode.IsUpper(r) {
+			if upperContext(runes, i) {
+				latin = strings.ToUpper(latin)
+			} else {
+				latin = strings.ToUpper(latin[:1]) + latin[1:]
+			}
+		}
+		sb.WriteString(latin)
+	}
+	return sb.String()
+}
+
+// Апостроф всередині слова (між кириличними літерами)
+func betweenLetters(runes []rune, i int) bool {
+	return i > 0 && i+1 < len(runes) &&
+		unicode.Is(unicode.Cyrillic, runes[i-1]) && unicode.Is(unicode.Cyrillic, runes[i+1])
+}
+
+// Чи починається слово з літери i (апостроф слово не розриває)
+func wordStartsAt(runes []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := runes[i-1]
+	if isApostrophe(prev) && i >= 2 && unicode.IsLetter(runes[i-2]) {
+		return false
+	}
+	return !unicode.IsLetter(prev)
+}
+
+// Чи написане слово великими літерами: тоді "Щ" стає "SHCH", а не "Shch"
+func upperContext(runes []rune, i int) bool {
+	if i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+		return unicode.IsUpper(runes[i+1])
+	}
+	return i > 0 && unicode.IsLetter(runes[i-1]) && unicode.IsUpper(runes[i-1])
+}
+
+// Рядок для URL: "Біла Церква, 2024" — "bila-tserkva-2024"
+func slugify(text string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(transliterate(text)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		// Апостроф в англійських словах не розриває slug: don't — dont
+		if isApostrophe(r) {
+			continue
+		}
+		dash = true
+	}
+	return sb.String()
+}
+
+// Транслітерує потік порядково; у режимі slug порожні рядки пропускаються
+func writeTransliteration(w io.Writer, r io.Reader, slug bool) error {
+	reader := bufio.NewReader(r)
+	out := bufio.NewWriter(w)
+	defer out.Flush()
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			if slug {
+				if s := slugify(line); s != "" {
+					out.WriteString(s + "\n")
+				}
+			} else {
+				out.WriteString(transliterate(line))
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import "testing"
+
+// Приклади з таблиці транслітерації (постанова КМУ від 27.01.2010 № 55)
+var officialTranslit = []struct{ uk, latin string }{
+	{"Алушта", "Alushta"}, {"Андрій", "Andrii"},
+	{"Борщагівка", "Borshchahivka"}, {"Борисенко", "Borysenko"},
+	{"Вінниця", "Vinnytsia"}, {"Володимир", "Volodymyr"},
+	{"Гадяч", "Hadiach"}, {"Богдан", "Bohdan"}, {"Згурський", "Zghurskyi"},
+	{"Ґалаґан", "Galagan"}, {"Ґорґани", "Gorgany"},
+	{"Донецьк", "Donetsk"}, {"Дмитро", "Dmytro"},
+	{"Рівне", "Rivne"}, {"Олег", "Oleh"}, {"Есмань", "Esman"},
+	{"Єнакієве", "Yenakiieve"}, {"Гаєвич", "Haievych"}, {"Короп'є", "Koropie"},
+	{"Житомир", "Zhytomyr"}, {"Жанна", "Zhanna"}, {"Жежелів", "Zhezheliv"},
+	{"Закарпаття", "Zakarpattia"}, {"Казимирчук", "Kazymyrchuk"},
+	{"Медвин", "Medvyn"}, {"Михайленко", "Mykhailenko"},
+	{"Іванків", "Ivankiv"}, {"Іващенко", "Ivashchenko"},
+	{"Їжакевич", "Yizhakevych"}, {"Кадиївка", "Kadyivka"}, {"Мар'їне", "Marine"},
+	{"Йосипівка", "Yosypivka"}, {"Стрий", "Stryi"}, {"Олексій", "Oleksii"},
+	{"Київ", "Kyiv"}, {"Коваленко", "Kovalenko"},
+	{"Лебедин", "Lebedyn"}, {"Леонід", "Leonid"},
+	{"Миколаїв", "Mykolaiv"}, {"Маринич", "Marynych"},
+	{"Ніжин", "Nizhyn"}, {"Наталія", "Nataliia"},
+	{"Одеса", "Odesa"}, {"Онищенко", "Onyshchenko"},
+	{"Полтава", "Poltava"}, {"Петро", "Petro"},
+	{"Решетилівка", "Reshetylivka"}, {"Рибчинський", "Rybchynskyi"},
+	{"Суми", "Sumy"}, {"Соломія", "Solomiia"},
+	{"Тернопіль", "Ternopil"}, {"Троць", "Trots"},
+	{"Ужгород", "Uzhhorod"}, {"Уляна", "Uliana"},
+	{"Фастів", "Fastiv"}, {"Філіпчук", "Filipchuk"},
+	{"Харків", "Kharkiv"}, {"Христина", "Khrystyna"},
+	{"Біла Церква", "Bila Tserkva"}, {"Стеценко", "Stetsenko"},
+	{"Чернівці", "Chernivtsi"}, {"Шевченко", "Shevchenko"},
+	{"Шостка", "Shostka"}, {"Кишеньки", "Kyshenky"},
+	{"Щербухи", "Shcherbukhy"}, {"Гоща", "Hoshcha"}, {"Гаращенко", "Harashchenko"},
+	{"Юрій", "Yurii"}, {"Корюківка", "Koriukivka"},
+	{"Яготин", "Yahotyn"}, {"Ярошенко", "Yaroshenko"}, {"Костянтин", "Kostiantyn"},
+	{"Знам'янка", "Znamianka"}, {"Феодосія", "Feodosiia"},
+	{"Згорани", "Zghorany"}, {"Розгон", "Rozghon"},
+}
+
+func TestTransliterateOfficialExamples(t *testing.T) {
+	for _, tc := range officialTranslit {
+		if got := transliterate(tc.uk); got != tc.latin {
+			t.Errorf("transliterate(%q) = %q, очікувалось %q", tc.uk, got, tc.latin)
+		}
+	}
+}
+
+func TestTransliterateText(t *testing.T) {
+	tests := []struct{ in, want string }{
+		// Регістр: слово великими літерами лишається великими
+		{"ЩАСТЯ", "SHCHASTIA"},
+		{"ЮРІЙ ЗГУРСЬКИЙ", "YURII ZGHURSKYI"},
+		{"Я", "Ya"},
+		// Початок слова — після пробілу чи розділового знака
+		{"моя яблуня, її їжак", "moia yablunia, yii yizhak"},
+		// Апостроф різних видів усередині слова не передається
+		{"м’ята пʼять сім'я", "miata piat simia"},
+		// Латиниця, цифри та апостроф в англійських словах не змінюються
+		{"Київ 2024: don't panic", "Kyiv 2024: don't panic"},
+	}
+	for _, tc := range tests {
+		if got := transliterate(tc.in); got != tc.want {
+			t.Errorf("transliterate(%q) = %q, очікувалось %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Біла Церква", "bila-tserkva"},
+		{"  Знам'янка — місто, 2024!  ", "znamianka-misto-2024"},
+		{"Що нового у Згуровці?", "shcho-novoho-u-zghurovtsi"},
+		{"Don't Stop", "dont-stop"},
+		{"---", ""},
+	}
+	for _, tc := range tests {
+		if got := slugify(tc.in); got != tc.want {
+			t.Errorf("slugify(%q) = %q, очікувалось %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -32,20 +32,20 @@ type Store struct {
 
 type Product struct {
 	ID          int
-	Name        string
+	Name        string `validate:"required"`
 	Description string
-	Price       float64
-	Category    string
-	Stock       int
+	Price       float64 `validate:"min=0"`
+	Category    string  `validate:"required"`
+	Stock       int     `validate:"min=0"`
 	IsActive    bool
 }
 
 type Customer struct {
 	ID      int
-	Name    string
-	Email   string
-	Phone   string
-	Address string
+	Name    string `validate:"required"`
+	Email   string `validate:"required,email"`
+	Phone   string `validate:"required,phone=UA"`
+	Address string `validate:"required"`
 }
 
 type CartItem struct {
@@ -62,8 +62,8 @@ type Cart struct {
 }
 
 type ShippingInfo struct {
-	Address      string
-	Method       string
+	Address      string `validate:"required"`
+	Method       string `validate:"required"`
 	Cost         float64
 	TrackingCode string
 }
@@ -71,15 +71,15 @@ type ShippingInfo struct {
 type OrderItem struct {
 	ProductID int
 	Name      string
-	UnitPrice float64
-	Quantity  int
+	UnitPrice float64 `validate:"min=0"`
+	Quantity  int     `validate:"min=1"`
 	LineTotal float64
 }
 
 type Order struct {
 	ID         int
 	CustomerID int
-	Items      []OrderItem
+	Items      []OrderItem `validate:"required"`
 	Subtotal   float64
 	Discount   float64
 	Shipping   float64
@@ -203,6 +203,12 @@ func addProduct(store *Store) {
 		return
 	}
 
+	candidate := Product{Name: name, Description: description, Price: price, Category: category, Stock: stock}
+	if errs := validateStruct(candidate); errs != nil {
+		errs.Print()
+		return
+	}
+
 	product := NewProduct(name, description, price, category, stock)
 	store.Products = append(store.Products, *product)
 
@@ -302,15 +308,23 @@ func editOrDeleteProduct(store *Store) {
 func updateProductFields(product *Product) {
 	fmt.Println("Оновлення даних товару (Enter — пропустити):")
 
+	updated := *product
 	if name, _ := getOptionalString("Нова назва: "); name != "" {
-		product.Name = name
+		updated.Name = name
+	}
+	// Від'ємні значення не відкидаються мовчки — їх відхилить валідація
+	if price, _ := getOptionalFloat("Нова ціна: "); price != 0 {
+		updated.Price = price
 	}
-	if price, _ := getOptionalFloat("Нова ціна: "); price > 0 {
-		product.Price = price
+	if stock, _ := getOptionalInt("Нова кількість: "); stock != 0 {
+		updated.Stock = stock
 	}
-	if stock, _ := getOptionalInt("Нова кількість: "); stock > 0 {
-		product.Stock = stock
+
+	if errs := validateStruct(updated); errs != nil {
+		errs.Print()
+		return
 	}
+	*product = updated
 	fmt.Println("Товар оновлено.")
 }
 
@@ -370,28 +384,11 @@ func registerCustomer(store *Store) {
 		return
 	}
 
-	valid, errs_phone := validatePhone(phone)
-	if !valid {
-		fmt.Println("Помилка у номері телефону:")
-		for _, e := range errs_phone {
-			fmt.Println("-", e)
-		}
-		return
-	}
-
 	email, err := getRequiredString("Введіть e-mail: ")
 	if err != nil {
 		fmt.Println("Помилка:", err)
 		return
 	}
-	valid, errs_email := validateEmail(email)
-	if !valid {
-		fmt.Println("Помилка у e-mail:")
-		for _, e := range errs_email {
-			fmt.Println("-", e)
-		}
-		return
-	}
 
 	address, err := getRequiredString("Введіть адресу: ")
 	if err != nil {
@@ -399,6 +396,13 @@ func registerCustomer(store *Store) {
 		return
 	}
 
+	// Перевіряємо всі поля разом, щоб показати помилки біля кожного з них
+	candidate := Customer{Name: name, Phone: phone, Email: email, Address: address}
+	if errs := validateStruct(candidate); errs != nil {
+		errs.Print()
+		return
+	}
+
 	customer := NewCustomer(name, phone, email, address)
 	store.Customers = append(store.Customers, *customer)
 
@@ -449,19 +453,26 @@ func updateCustomer(store *Store) {
 	newEmail, _ := getOptionalString("Новий e-mail (Enter — без змін): ")
 	newAddress, _ := getOptionalString("Нова адреса (Enter — без змін): ")
 
+	updated := store.Customers[index]
 	if newName != "" {
-		store.Customers[index].Name = newName
+		updated.Name = newName
 	}
 	if newPhone != "" {
-		store.Customers[index].Phone = newPhone
+		updated.Phone = newPhone
 	}
 	if newEmail != "" {
-		store.Customers[index].Email = newEmail
+		updated.Email = newEmail
 	}
 	if newAddress != "" {
-		store.Customers[index].Address = newAddress
+		updated.Address = newAddress
 	}
 
+	if errs := validateStruct(updated); errs != nil {
+		errs.Print()
+		return
+	}
+	store.Customers[index] = updated
+
 	fmt.Println("Дані клієнта оновлено успішно!")
 }
 
@@ -751,6 +762,12 @@ func createOrderFromCart(store *Store) {
 		})
 	}
 
+	// Кошик не очищується, якщо замовлення не пройшло перевірку
+	if errs := validateStruct(order); errs != nil {
+		errs.Print()
+		return
+	}
+
 	store.Orders = append(store.Orders, order)
 	delete(store.Carts, id)
 
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ---------- Валідація структур за тегами ----------
+// Правила задаються тегом `validate:"правило1,правило2=параметр"`.
+// Вкладені структури, вказівники, слайси та мапи обходяться рекурсивно,
+// а помилки збираються за шляхом до поля: "Email", "Items[0].Quantity".
+
+// Валідатор поля: отримує значення та параметр правила (після '=')
+type FieldValidator func(value reflect.Value, param string) []string
+
+// Помилки валідації, згруповані за шляхом до поля
+type ValidationErrors map[string][]string
+
+var fieldValidators = map[string]FieldValidator{}
+
+func init() {
+	registerValidator("required", validateRequired)
+	registerValidator("min", validateMin)
+	registerValidator("max", validateMax)
+	registerValidator("email", func(v reflect.Value, _ string) []string {
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+		_, errs := validateEmail(v.String())
+		return errs
+	})
+	registerValidator("phone", validatePhoneRule)
+}
+
+// Реєструє валідатор під іменем, яке потім можна вказати у тегу
+func registerValidator(name string, fn FieldValidator) {
+	fieldValidators[name] = fn
+}
+
+func (e ValidationErrors) Error() string {
+	var lines []string
+	for _, path := range e.Fields() {
+		lines = append(lines, path+": "+strings.Join(e[path], "; "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Шляхи полів з помилками у відсортованому порядку
+func (e ValidationErrors) Fields() []string {
+	paths := make([]string, 0, len(e))
+	for path := range e {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Виводить помилки у форматі, який використовується у меню
+func (e ValidationErrors) Print() {
+	for _, path := range e.Fields() {
+		fmt.Printf("Помилка у полі %s:\n", path)
+		for _, msg := range e[path] {
+			fmt.Println("-", msg)
+		}
+	}
+}
+
+// Перевіряє структуру (або вказівник на неї) за тегами validate.
+// Повертає nil, якщо помилок немає.
+func validateStruct(s any) ValidationErrors {
+	errs := ValidationErrors{}
+	validateValue(reflect.ValueOf(s), "", errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Рекурсивно обходить значення, накопичуючи помилки у errs
+func validateValue(v reflect.Value, path string, errs ValidationErrors) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			validateValue(v.Elem(), path, errs)
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := joinPath(path, field.Name)
+			value := v.Field(i)
+
+			if tag := field.Tag.Get("validate"); tag != "" && tag != "-" {
+				applyRules(value, tag, fieldPath, errs)
+			}
+			validateValue(value, fieldPath, errs)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			validateValue(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key.Interface()), errs)
+		}
+	}
+}
+
+// Застосовує правила з тегу до одного поля
+func applyRules(value reflect.Value, tag, path string, errs ValidationErrors) {
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		fn, ok := fieldValidators[name]
+		if !ok {
+			errs[path] = append(errs[path], "Невідоме правило валідації: "+name)
+			continue
+		}
+		errs[path] = append(errs[path], fn(value, param)...)
+		if len(errs[path]) == 0 {
+			delete(errs, path)
+		}
+		// Якщо обов'язкове поле порожнє, інші правила не мають сенсу
+		if name == "required" && len(errs[path]) > 0 {
+			return
+		}
+	}
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// ---- Вбудовані правила ----
+
+func validateRequired(v reflect.Value, _ string) []string {
+	switch v.Kind() {
+	case reflect.String:
+		if strings.TrimSpace(v.String()) == "" {
+			return []string{"Поле обов'язкове"}
+		}
+	case reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return []string{"Поле обов'язкове"}
+		}
+	default:
+		if v.IsZero() {
+			return []string{"Поле обов'язкове"}
+		}
+	}
+	return nil
+}
+
+// Числове значення, довжина рядка (у символах) або кількість елементів
+func measure(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), true
+	}
+	return 0, false
+}
+
+func validateMin(v reflect.Value, param string) []string {
+	limit, err := strconv.ParseFloat(param, 64)
+	n, ok := measure(v)
+	if err != nil || !ok {
+		return []string{"Некоректне правило min=" + param}
+	}
+	if n < limit {
+		return []string{fmt.Sprintf("Значення має бути не менше %s", param)}
+	}
+	return nil
+}
+
+func validateMax(v reflect.Value, param string) []string {
+	limit, err := strconv.ParseFloat(param, 64)
+	n, ok := measure(v)
+	if err != nil || !ok {
+		return []string{"Некоректне правило max=" + param}
+	}
+	if n > limit {
+		return []string{fmt.Sprintf("Значення має бути не більше %s", param)}
+	}
+	return nil
+}
+
+// phone або phone=UA: для UA додатково вимагається код +380 та 12 цифр
+func validatePhoneRule(v reflect.Value, param string) []string {
+	if v.Kind() != reflect.String || v.String() == "" {
+		return nil
+	}
+	phone := v.String()
+	_, errs := validatePhone(phone)
+
+	switch param {
+	case "":
+	case "UA":
+		digits := 0
+		for _, ch := range phone {
+			if ch >= '0' && ch <= '9' {
+				digits++
+			}
+		}
+		if !strings.HasPrefix(phone, "+380") || digits != 12 {
+			errs = append(errs, "Очікується український номер у форматі +380XXXXXXXXX")
+		}
+	default:
+		errs = append(errs, "Непідтримуваний код країни: "+param)
+	}
+	return errs
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func validOrder() Order {
+	return Order{
+		ID:         1,
+		CustomerID: 1,
+		Items: []OrderItem{
+			{ProductID: 1, Name: "Миша", UnitPrice: 500, Quantity: 2, LineTotal: 1000},
+			{ProductID: 2, Name: "Кабель", UnitPrice: 100, Quantity: 1, LineTotal: 100},
+		},
+		ShippingTo: &ShippingInfo{Address: "Київ, вул. Хрещатик, 1", Method: "Стандартна доставка"},
+	}
+}
+
+func TestValidateOrderValid(t *testing.T) {
+	if errs := validateStruct(validOrder()); errs != nil {
+		t.Errorf("unexpected errors:\n%v", errs)
+	}
+}
+
+func TestValidateOrderFieldPaths(t *testing.T) {
+	order := validOrder()
+	order.Items[1].Quantity = 0
+	order.Items[1].UnitPrice = -5
+	order.ShippingTo.Method = " "
+
+	errs := validateStruct(&order)
+	want := []string{"Items[1].Quantity", "Items[1].UnitPrice", "ShippingTo.Method"}
+	if got := errs.Fields(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("fields = %q, want %q", got, want)
+	}
+	if msg := errs["Items[1].Quantity"][0]; !strings.Contains(msg, "не менше 1") {
+		t.Errorf("Items[1].Quantity: %q", msg)
+	}
+}
+
+func TestValidateOrderRequiredItems(t *testing.T) {
+	order := validOrder()
+	order.Items = nil
+	order.ShippingTo = nil // nil-вказівник не перевіряється
+
+	errs := validateStruct(order)
+	if got := errs.Fields(); !reflect.DeepEqual(got, []string{"Items"}) {
+		t.Errorf("fields = %q, want [Items]", got)
+	}
+}
+
+func TestValidateProduct(t *testing.T) {
+	errs := validateStruct(Product{Name: "", Price: -1, Category: "Периферія", Stock: -3})
+	want := []string{"Name", "Price", "Stock"}
+	if got := errs.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+}
+
+func TestValidateCustomerRequiredStopsOtherRules(t *testing.T) {
+	errs := validateStruct(Customer{Name: "Іван", Email: "", Phone: "+380501234567", Address: "Київ"})
+	if got := errs["Email"]; len(got) != 1 || got[0] != "Поле обов'язкове" {
+		t.Errorf("Email errors = %q", got)
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	registerValidator("sku", func(v reflect.Value, param string) []string {
+		if !strings.HasPrefix(v.String(), param+"-") {
+			return []string{"Артикул має починатися з " + param + "-"}
+		}
+		return nil
+	})
+	defer delete(fieldValidators, "sku")
+
+	type line struct {
+		SKU string `validate:"required,sku=TS"`
+	}
+	type catalog struct {
+		Lines map[string]line
+		Main  line
+	}
+
+	errs := validateStruct(catalog{
+		Lines: map[string]line{"a": {SKU: "TS-1"}, "b": {SKU: "XX-2"}},
+		Main:  line{SKU: "TS-9"},
+	})
+	if got := errs.Fields(); !reflect.DeepEqual(got, []string{"Lines[b].SKU"}) {
+		t.Fatalf("fields = %q, want [Lines[b].SKU]", got)
+	}
+	if got := errs["Lines[b].SKU"][0]; got != "Артикул має починатися з TS-" {
+		t.Errorf("message = %q", got)
+	}
+}
+
+func TestUnknownRule(t *testing.T) {
+	type bad struct {
+		Code string `validate:"uuid"`
+	}
+	errs := validateStruct(bad{Code: "x"})
+	if got := errs["Code"]; len(got) != 1 || !strings.Contains(got[0], "uuid") {
+		t.Errorf("Code errors = %q", got)
+	}
+}
@@ -0,0 +1,3 @@
+module golanghomeworks
+
+go 1.22
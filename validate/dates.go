@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ---------- Перевірка дат ----------
+
+// Підтримувані формати дати (у порядку спроби) та їхній вигляд: рядок,
+// що має вигляд формату, але не розбирається, — неіснуюча дата
+var dateLayouts = []struct {
+	layout string
+	shape  *regexp.Regexp
+}{
+	{"02.01.2006", regexp.MustCompile(`^\d{2}\.\d{2}\.\d{4}$`)},
+	{"2.1.2006", regexp.MustCompile(`^\d{1,2}\.\d{1,2}\.\d{4}$`)},
+	{"2006-01-02", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)},
+	{"02/01/2006", regexp.MustCompile(`^\d{2}/\d{2}/\d{4}$`)},
+	{"02-01-2006", regexp.MustCompile(`^\d{2}-\d{2}-\d{4}$`)},
+}
+
+// Обмеження для дати. Нульові поля означають відсутність обмеження.
+type DateRule struct {
+	Min    time.Time
+	Max    time.Time
+	MinAge int
+	MaxAge int
+}
+
+// Розбирає дату в одному з підтримуваних форматів.
+// time.Parse сам відкидає неіснуючі дати (31.04, 29.02 у невисокосний рік).
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	for _, f := range dateLayouts {
+		if !f.shape.MatchString(value) {
+			continue
+		}
+		t, err := time.Parse(f.layout, value)
+		if err != nil {
+			return time.Time{}, errors.New("такої дати не існує")
+		}
+		return t, nil
+	}
+	return time.Time{}, errors.New("невідомий формат (очікується ДД.ММ.РРРР або РРРР-ММ-ДД)")
+}
+
+// Кількість повних років між from та to
+func FullYears(from, to time.Time) int {
+	years := to.Year() - from.Year()
+	if to.Month() < from.Month() || (to.Month() == from.Month() && to.Day() < from.Day()) {
+		years--
+	}
+	return years
+}
+
+// Перевірка дати за правилом
+func Date(value string, rule DateRule) (bool, []string) {
+	var errors []string
+
+	date, err := ParseDate(value)
+	if err != nil {
+		return false, []string{"Некоректна дата: " + err.Error()}
+	}
+
+	if !rule.Min.IsZero() && date.Before(rule.Min) {
+		errors = append(errors, "Дата раніше за "+rule.Min.Format("02.01.2006"))
+	}
+	if !rule.Max.IsZero() && date.After(rule.Max) {
+		errors = append(errors, "Дата пізніше за "+rule.Max.Format("02.01.2006"))
+	}
+
+	if rule.MinAge > 0 || rule.MaxAge > 0 {
+		now := time.Now()
+		age := FullYears(date, now)
+		if date.After(now) {
+			errors = append(errors, "Дата народження не може бути в майбутньому")
+		} else if rule.MinAge > 0 && age < rule.MinAge {
+			errors = append(errors, fmt.Sprintf("Вік %d менший за мінімальний (%d)", age, rule.MinAge))
+		} else if rule.MaxAge > 0 && age > rule.MaxAge {
+			errors = append(errors, fmt.Sprintf("Вік %d більший за максимальний (%d)", age, rule.MaxAge))
+		}
+	}
+
+	return len(errors) == 0, errors
+}
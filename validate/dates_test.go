@@ -0,0 +1,32 @@
+package validate
+
+import "testing"
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value, want, err string
+	}{
+		{"29.02.2024", "2024-02-29", ""},
+		{"5.3.2024", "2024-03-05", ""},
+		{"2024-03-15", "2024-03-15", ""},
+		{"15/03/2024", "2024-03-15", ""},
+		{"15-03-2024", "2024-03-15", ""},
+		{"31.04.2024", "", "такої дати не існує"},
+		{"29.02.2023", "", "такої дати не існує"},
+		{"2024-13-01", "", "такої дати не існує"},
+		{"00.01.2024", "", "такої дати не існує"},
+		{"2024-3-5", "", "невідомий формат (очікується ДД.ММ.РРРР або РРРР-ММ-ДД)"},
+		{"15 березня", "", "невідомий формат (очікується ДД.ММ.РРРР або РРРР-ММ-ДД)"},
+	}
+	for _, tc := range tests {
+		got, err := ParseDate(tc.value)
+		switch {
+		case tc.err != "" && (err == nil || err.Error() != tc.err):
+			t.Errorf("ParseDate(%q): err = %v, want %q", tc.value, err, tc.err)
+		case tc.err == "" && err != nil:
+			t.Errorf("ParseDate(%q): unexpected error %v", tc.value, err)
+		case tc.err == "" && got.Format("2006-01-02") != tc.want:
+			t.Errorf("ParseDate(%q) = %s, want %s", tc.value, got.Format("2006-01-02"), tc.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Пакет validate — спільні правила перевірки значень для домашніх завдань:
+// email, телефон, IP, URL, дати, а також списки доменів верхнього рівня та
+// Public Suffix List. Ним користуються валідатор даних (HW2) та пошук
+// сутностей в аналізаторі тексту (HW3), тож правила існують в одному місці.
+package validate
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ---------- Домени верхнього рівня та публічні суфікси ----------
+// Знімки списку TLD від IANA та Public Suffix List вбудовані у бінарник.
+// Команди "hw2 update-tld" / "update-psl" зберігають свіжу копію у каталозі
+// конфігурації користувача, і вона має пріоритет над вбудованою для всіх
+// програм, що користуються пакетом.
+
+//go:embed data/tlds-alpha-by-domain.txt
+var bundledTLDs string
+
+//go:embed data/public_suffix_list.dat
+var bundledPSL string
+
+// Імена файлів знімків у каталозі даних
+const (
+	TLDFileName = "tlds-alpha-by-domain.txt"
+	PSLFileName = "public_suffix_list.dat"
+)
+
+// Множина відомих TLD (нижній регістр, IDN у вигляді punycode та Unicode)
+var knownTLDs map[string]bool
+
+// Правила Public Suffix List
+var (
+	pslRules      map[string]bool // звичайні правила: "com.ua"
+	pslWildcards  map[string]bool // "*.ck" зберігається як "ck"
+	pslExceptions map[string]bool // "!www.ck" зберігається як "www.ck"
+)
+
+// Завантажує списки: спершу оновлені копії користувача, інакше вбудовані.
+func LoadDomainLists() error {
+	tlds, psl := bundledTLDs, bundledPSL
+
+	if dir, err := dataDir(); err == nil {
+		if data, err := os.ReadFile(filepath.Join(dir, TLDFileName)); err == nil {
+			tlds = string(data)
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, PSLFileName)); err == nil {
+			psl = string(data)
+		}
+	}
+
+	knownTLDs = parseTLDList(tlds)
+	parsePSL(psl)
+	if len(knownTLDs) == 0 {
+		return errors.New("список TLD порожній")
+	}
+	return nil
+}
+
+// Каталог, куди зберігаються оновлені списки
+func dataDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hw2"), nil
+}
+
+// Формат IANA: рядок-коментар "# Version ..." і далі по одному TLD на рядок.
+func parseTLDList(data string) map[string]bool {
+	set := make(map[string]bool)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		set[strings.ToLower(line)] = true
+	}
+	return set
+}
+
+// Формат PSL: коментарі "//", правила по одному на рядок,
+// "*." — шаблон, "!" — виняток. Верхні мітки правил ICANN також
+// поповнюють множину TLD (це дає Unicode-форми IDN TLD, напр. "укр").
+func parsePSL(data string) {
+	pslRules = make(map[string]bool)
+	pslWildcards = make(map[string]bool)
+	pslExceptions = make(map[string]bool)
+
+	icann := true
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "===END ICANN DOMAINS===") {
+			icann = false
+		}
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		rule := strings.ToLower(strings.Fields(line)[0])
+
+		switch {
+		case strings.HasPrefix(rule, "!"):
+			pslExceptions[rule[1:]] = true
+		case strings.HasPrefix(rule, "*."):
+			pslWildcards[rule[2:]] = true
+		default:
+			pslRules[rule] = true
+		}
+
+		if icann && knownTLDs != nil {
+			labels := strings.Split(strings.TrimLeft(rule, "!*."), ".")
+			knownTLDs[labels[len(labels)-1]] = true
+		}
+	}
+}
+
+// Перевіряє, чи відомий домен верхнього рівня
+func IsKnownTLD(tld string) bool {
+	return knownTLDs[strings.ToLower(tld)]
+}
+
+// Повертає публічний суфікс домену за алгоритмом PSL:
+// найдовше правило, винятки мають пріоритет, за замовчуванням — "*".
+func PublicSuffix(domain string) string {
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(domain, ".")), ".")
+
+	for i := range labels {
+		candidate := strings.Join(labels[i:], ".")
+		if pslExceptions[candidate] {
+			return strings.Join(labels[i+1:], ".")
+		}
+		if pslRules[candidate] {
+			return candidate
+		}
+		if i+1 < len(labels) && pslWildcards[strings.Join(labels[i+1:], ".")] {
+			return candidate
+		}
+	}
+	return labels[len(labels)-1]
+}
+
+// Повертає зареєстрований домен (публічний суфікс + одна мітка),
+// напр. "mail.example.com.ua" -> "example.com.ua".
+func RegistrableDomain(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	suffix := PublicSuffix(domain)
+	if domain == suffix {
+		return "", fmt.Errorf("домен %q є публічним суфіксом", domain)
+	}
+
+	rest := strings.TrimSuffix(domain, "."+suffix)
+	labels := strings.Split(rest, ".")
+	return labels[len(labels)-1] + "." + suffix, nil
+}
+
+// Перевірка домену: відомий TLD і наявність зареєстрованої частини.
+// Використовується валідаторами email та URL.
+func validateDomainSuffix(domain string) []string {
+	var errors []string
+
+	labels := strings.Split(strings.TrimSuffix(domain, "."), ".")
+	tld := labels[len(labels)-1]
+	if !IsKnownTLD(tld) {
+		errors = append(errors, "Невідомий домен верхнього рівня: ."+tld)
+		return errors
+	}
+	if _, err := RegistrableDomain(domain); err != nil {
+		errors = append(errors, "Домен є публічним суфіксом, а не адресою сайту: "+domain)
+	}
+	return errors
+}
+
+// Оновлює знімок списку з локального файлу. Файл спершу розбирається,
+// щоб не зберегти порожній або пошкоджений список.
+func UpdateDomainList(fileName, source string) error {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	switch fileName {
+	case TLDFileName:
+		if len(parseTLDList(string(data))) == 0 {
+			return errors.New("у файлі не знайдено жодного TLD")
+		}
+	case PSLFileName:
+		if !strings.Contains(string(data), "===BEGIN ICANN DOMAINS===") {
+			return errors.New("файл не схожий на Public Suffix List")
+		}
+	}
+
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, fileName), data, 0o644)
+}
@@ -0,0 +1,92 @@
+package validate
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ---------- Нормалізація ----------
+// Нормалізатори приводять введене значення до канонічного вигляду
+// перед перевіркою. Вони нічого не валідують: якщо значення неможливо
+// розібрати, повертається обрізаний вхідний рядок.
+
+// Обрізає пробіли та переводить доменну частину email у нижній регістр.
+// Локальна частина залишається без змін, бо вона може бути чутливою до регістру.
+func NormalizeEmail(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return email
+	}
+	return email[:at+1] + strings.ToLower(email[at+1:])
+}
+
+// Приводить номер до формату E.164: "(050) 123-45-67" -> "+380501234567".
+// Номери без коду країни вважаються українськими.
+func NormalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+
+	clean := ""
+	for _, ch := range phone {
+		if unicode.IsDigit(ch) {
+			clean += string(ch)
+		} else if !strings.ContainsRune("+-(). ", ch) {
+			return phone // сторонні символи — залишаємо як є, нехай валідатор пояснить
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(phone, "+"):
+		return "+" + clean
+	case strings.HasPrefix(clean, "380") && len(clean) == 12:
+		return "+" + clean
+	case strings.HasPrefix(clean, "0") && len(clean) == 10:
+		return "+38" + clean
+	}
+	return phone
+}
+
+// Обрізає пробіли, переводить схему та хост у нижній регістр
+// і прибирає порт за замовчуванням (:80 для http, :443 для https).
+func NormalizeURL(url string) string {
+	url = strings.TrimSpace(url)
+
+	sep := strings.Index(url, "://")
+	if sep == -1 {
+		return url
+	}
+	scheme := strings.ToLower(url[:sep])
+	rest := url[sep+3:]
+
+	host, tail := rest, ""
+	if idx := strings.IndexAny(rest, "/?#"); idx != -1 {
+		host, tail = rest[:idx], rest[idx:]
+	}
+	host = strings.ToLower(host)
+
+	if (scheme == "http" && strings.HasSuffix(host, ":80")) ||
+		(scheme == "https" && strings.HasSuffix(host, ":443")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+
+	return scheme + "://" + host + tail
+}
+
+// Для пароля та IP нормалізація зводиться до обрізання пробілів.
+func NormalizeIP(ip string) string {
+	return strings.TrimSpace(ip)
+}
+
+// Виділяє хост з URL (без схеми, порту, шляху та параметрів)
+func URLHost(url string) string {
+	if sep := strings.Index(url, "://"); sep != -1 {
+		url = url[sep+3:]
+	}
+	if idx := strings.IndexAny(url, "/?#"); idx != -1 {
+		url = url[:idx]
+	}
+	if idx := strings.LastIndex(url, ":"); idx != -1 {
+		url = url[:idx]
+	}
+	return url
+}
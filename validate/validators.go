@@ -0,0 +1,163 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ---------- Валідатори ----------
+// Кожен валідатор повертає ознаку валідності та список причин помилок.
+// Значення слід спершу нормалізувати (NormalizeEmail, NormalizePhone...).
+
+// Перевірка email-адреси
+func Email(email string) (bool, []string) {
+	var errors []string
+
+	if strings.Count(email, "@") == 0 {
+		errors = append(errors, "Немає символа '@'")
+	} else if strings.Count(email, "@") > 1 {
+		errors = append(errors, "Більше ніж один символ '@'")
+	}
+
+	parts := strings.SplitN(email, "@", 2)
+	if len(parts) < 2 {
+		return false, errors
+	}
+	local := parts[0]
+	domain := parts[1]
+
+	if len(local) < 1 {
+		errors = append(errors, "Порожня локальна частина (до @)")
+	}
+	if len(domain) < 1 {
+		errors = append(errors, "Порожня доменна частина (після @)")
+	}
+	if strings.Contains(email, " ") {
+		errors = append(errors, "У адресі є пробіли")
+	}
+	if !regexp.MustCompile(`^[A-Za-z0-9._-]+$`).MatchString(local) {
+		errors = append(errors, "Недозволені символи") // (у локальній частині)
+	}
+	if !strings.Contains(domain, ".") {
+		errors = append(errors, "У доменній частині немає крапки '.'")
+	} else {
+		errors = append(errors, validateDomainSuffix(domain)...)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Перевірка телефонного номера
+func Phone(phone string) (bool, []string) {
+	var errors []string
+	digits := 0
+
+	if !strings.HasPrefix(phone, "+") {
+		errors = append(errors, "Номер має починатися з '+' (міжнародний формат)")
+	}
+
+	for _, ch := range phone {
+		if unicode.IsDigit(ch) {
+			digits++
+		} else if strings.ContainsRune("+-() ", ch) {
+			continue
+		} else {
+			errors = append(errors, fmt.Sprintf("Недозволений символ: %q", ch))
+		}
+	}
+
+	if digits < 10 || digits > 15 {
+		errors = append(errors, "Кількість цифр має бути від 10 до 15")
+	}
+
+	clean := ""
+	for _, ch := range phone {
+		if unicode.IsDigit(ch) {
+			clean += string(ch)
+		}
+	}
+
+	if strings.HasPrefix(phone, "+380") {
+		if len(clean) != 12 {
+			errors = append(errors, "Український номер повинен мати 12 цифр")
+		}
+		if len(clean) >= 5 {
+			operator := clean[2:5] // "380" + "50..." -> код оператора "050"
+			validOperators := []string{"050", "063", "066", "067", "068", "091", "092", "093", "094", "095", "096", "097", "098", "099"}
+			found := false
+			for _, op := range validOperators {
+				if operator == op {
+					found = true
+					break
+				}
+			}
+			if !found {
+				errors = append(errors, "Невідомий код оператора: "+operator)
+			}
+		}
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Перевірка IP-адреси
+func IP(ip string) (bool, []string) {
+	var errors []string
+
+	parts := strings.Split(ip, ".")
+	if len(parts) != 4 {
+		errors = append(errors, "Не відповідає формату X.X.X.X")
+	}
+	if strings.Contains(ip, " ") {
+		errors = append(errors, "IP-адреса містить пробіли")
+	}
+
+	for _, p := range parts {
+		num, err := strconv.Atoi(p)
+		if err != nil {
+			errors = append(errors, "Частина не є числом: "+p)
+			continue
+		}
+		if num < 0 || num > 255 {
+			errors = append(errors, fmt.Sprintf("Частина виходить за межі 0–255: %d", num))
+		}
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Перевірка URL-адреси
+func URL(url string) (bool, []string) {
+	var errors []string
+
+	if strings.HasPrefix(url, "http://") {
+		url = strings.TrimPrefix(url, "http://")
+	} else if strings.HasPrefix(url, "https://") {
+		url = strings.TrimPrefix(url, "https://")
+	} else {
+		errors = append(errors, "Відсутній протокол (http:// або https://)")
+	}
+
+	if idx := strings.IndexAny(url, "/?#"); idx != -1 {
+		url = url[:idx]
+	}
+
+	if !strings.Contains(url, ".") {
+		errors = append(errors, "У доменній частині немає крапки '.'")
+	} else {
+		errors = append(errors, validateDomainSuffix(url)...)
+	}
+
+	if !regexp.MustCompile(`^[A-Za-z0-9._-]+$`).MatchString(url) {
+		errors = append(errors, "Домен містить недозволені символи")
+	}
+
+	if strings.Contains(url, " ") {
+		errors = append(errors, "Домен містить пробіли")
+	}
+
+	return len(errors) == 0, errors
+}